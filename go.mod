@@ -2,7 +2,8 @@ module github.com/daotl/go-web-common
 
 go 1.25
 
-require (
-	github.com/nicksnyder/go-i18n/v2 v2.6.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
-)
+require github.com/nicksnyder/go-i18n/v2 v2.6.0
+
+require golang.org/x/text v0.32.0
+
+require github.com/BurntSushi/toml v1.5.0
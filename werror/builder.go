@@ -0,0 +1,128 @@
+package werror
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrBuilder builds a new Err derived from a base Err without modifying the base.
+type ErrBuilder struct {
+	base      Err
+	namespace string
+	code      ErrCode
+	msg       string
+	subErrors []Err
+	params    map[string]any
+	fields    map[string][]FieldError
+}
+
+// NewErrBuilder creates an ErrBuilder starting from base.
+func NewErrBuilder(base Err) *ErrBuilder {
+	b := &ErrBuilder{
+		base:      base,
+		namespace: namespaceOf(base),
+		code:      base.GetCode(),
+		msg:       base.GetMessage(),
+		subErrors: slices.Clone(base.GetSubErrors()),
+	}
+	if params, ok := base.GetMetadata().(map[string]any); ok {
+		b.params = maps.Clone(params)
+	}
+	if verr, ok := base.(*ValidationErr); ok {
+		b.fields = cloneFields(verr.Fields)
+	}
+	return b
+}
+
+// WithParam sets a key-value pair in the built Err's Metadata.
+// Metadata of the base Err is kept only if it is a map[string]any.
+func (b *ErrBuilder) WithParam(key string, val any) *ErrBuilder {
+	if b.params == nil {
+		b.params = map[string]any{}
+	}
+	b.params[key] = val
+	return b
+}
+
+// WithCode overrides the code of the built Err, blank codes are ignored.
+func (b *ErrBuilder) WithCode(code ErrCode) *ErrBuilder {
+	if strings.TrimSpace(string(code)) != "" {
+		b.code = code
+	}
+	return b
+}
+
+// WithNamespace sets the namespace of the built Err's code, e.g. "order" for "order.NotFound".
+// An empty namespace removes it.
+func (b *ErrBuilder) WithNamespace(ns string) *ErrBuilder {
+	b.namespace = strings.TrimSpace(ns)
+	return b
+}
+
+// WithMessage overrides the message of the built Err, blank messages are ignored.
+func (b *ErrBuilder) WithMessage(msg string) *ErrBuilder {
+	if msg = strings.TrimSpace(msg); msg != "" {
+		b.msg = msg
+	}
+	return b
+}
+
+// WithDetails appends errs to the sub-errors of the built Err.
+func (b *ErrBuilder) WithDetails(errs ...Err) *ErrBuilder {
+	b.subErrors = append(b.subErrors, errs...)
+	return b
+}
+
+// AddFieldError appends a DetailItem for field with reason to the sub-errors of the built Err.
+func (b *ErrBuilder) AddFieldError(field, reason string) *ErrBuilder {
+	return b.WithDetails(NewDetailItem(field, reason))
+}
+
+// AddFieldCodeError adds an error with code and msg for field, which makes Build create a ValidationErr.
+func (b *ErrBuilder) AddFieldCodeError(field string, code ErrCode, msg string) *ErrBuilder {
+	if b.fields == nil {
+		b.fields = map[string][]FieldError{}
+	}
+	b.fields[field] = append(b.fields[field], FieldError{Code: code, Message: msg})
+	return b
+}
+
+// LegacyAddFieldError adds an error with msg but without code for field like AddFieldCodeError,
+// for code written before field errors had codes. Prefer AddFieldCodeError.
+func (b *ErrBuilder) LegacyAddFieldError(field, msg string) *ErrBuilder {
+	return b.AddFieldCodeError(field, "", msg)
+}
+
+// Build creates a new Err wrapping the base Err, a *ValidationErr if field errors were added with
+// AddFieldCodeError or the base Err is one. Besides what was set on the builder, the Err has the origin, headers,
+// payload and severity of the base Err. The builder can be reused, later changes do not affect Errs already built.
+func (b *ErrBuilder) Build() Err {
+	var meta any
+	if b.params != nil {
+		meta = maps.Clone(b.params)
+	} else {
+		meta = b.base.GetMetadata()
+	}
+	code := namespacedCode(b.namespace, b.code)
+	serr := &Serr{
+		error:      fmt.Errorf("%w: %s %s", b.base, code, b.msg),
+		HttpStatus: b.base.GetHttpStatus(),
+		Code:       code,
+		Namespace:  b.namespace,
+		Message:    b.msg,
+		SubErrors:  slices.Clone(b.subErrors),
+		Metadata:   meta,
+		Origin:     OriginOf(b.base),
+	}
+	if base := serrOf(b.base); base != nil {
+		serr.payload = base.payload
+		serr.headers = base.headers.Clone()
+		serr.severity = base.severity
+	}
+	if b.fields != nil {
+		return &ValidationErr{Serr: serr, Fields: cloneFields(b.fields)}
+	}
+	return serr
+}
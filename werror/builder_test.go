@@ -0,0 +1,207 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrBuilder_Build(t *testing.T) {
+	detail := NewErr(ErrInvalidInput, "Email is invalid", "")
+
+	err := NewErrBuilder(ErrBadRequest).
+		WithCode("UserInvalid").
+		WithMessage("The user is invalid").
+		WithParam("userId", 7).
+		WithParam("field", "email").
+		WithDetails(detail).
+		Build()
+
+	if err.GetCode() != "UserInvalid" {
+		t.Errorf("GetCode() = %v, want UserInvalid", err.GetCode())
+	}
+	if err.GetMessage() != "The user is invalid" {
+		t.Errorf("GetMessage() = %v, want 'The user is invalid'", err.GetMessage())
+	}
+	if err.GetHttpStatus() != http.StatusBadRequest {
+		t.Errorf("GetHttpStatus() = %v, want %v", err.GetHttpStatus(), http.StatusBadRequest)
+	}
+	wantMeta := map[string]any{"userId": 7, "field": "email"}
+	if !reflect.DeepEqual(err.GetMetadata(), wantMeta) {
+		t.Errorf("GetMetadata() = %v, want %v", err.GetMetadata(), wantMeta)
+	}
+	if !reflect.DeepEqual(err.GetSubErrors(), []Err{detail}) {
+		t.Errorf("GetSubErrors() = %v, want %v", err.GetSubErrors(), []Err{detail})
+	}
+	if !errors.Is(NewErrBuilder(ErrBadRequest).WithMessage("Custom").Build(), ErrBadRequest) {
+		t.Error("Built error with the base code should be Is base error")
+	}
+}
+
+func TestErrBuilder_BaseUnchanged(t *testing.T) {
+	base := NewBaseErr(http.StatusNotFound, "OrderNotFound", "Order not found")
+	base.SetMetadata(map[string]any{"service": "order"})
+	base.AddSubErrors(ErrResourceNotFound)
+
+	err := NewErrBuilder(base).
+		WithCode("OrderGone").
+		WithMessage("Order is gone").
+		WithParam("orderId", "o-1").
+		WithDetails(ErrConflict).
+		Build()
+
+	if base.GetCode() != "OrderNotFound" || base.GetMessage() != "Order not found" {
+		t.Errorf("base changed to %v %v", base.GetCode(), base.GetMessage())
+	}
+	if !reflect.DeepEqual(base.GetMetadata(), map[string]any{"service": "order"}) {
+		t.Errorf("base Metadata changed to %v", base.GetMetadata())
+	}
+	if len(base.GetSubErrors()) != 1 {
+		t.Errorf("base SubErrors changed to %v", base.GetSubErrors())
+	}
+
+	wantMeta := map[string]any{"service": "order", "orderId": "o-1"}
+	if !reflect.DeepEqual(err.GetMetadata(), wantMeta) {
+		t.Errorf("GetMetadata() = %v, want %v", err.GetMetadata(), wantMeta)
+	}
+	if len(err.GetSubErrors()) != 2 {
+		t.Errorf("GetSubErrors() len = %v, want 2", len(err.GetSubErrors()))
+	}
+}
+
+func TestErrBuilder_CopiesAllFields(t *testing.T) {
+	remote, _ := NewRemoteErr(http.StatusConflict, "OrderConflict", "Order conflict").(*Serr)
+	base := WithPayload(ErrWithHTTPHeaders(remote.WithSeverity(SeverityCritical),
+		http.Header{"X-Order": {"o-1"}}), "order o-1")
+
+	err := NewErrBuilder(base).WithMessage("Order o-1 conflicts").Build()
+
+	//nolint:errcheck // type must match
+	if got := err.(HeaderedError).GetHTTPHeaders(); got.Get("X-Order") != "o-1" {
+		t.Errorf("GetHTTPHeaders() = %v, want the base headers", got)
+	}
+	if payload, _ := serrOf(err).payload.(string); payload != "order o-1" {
+		t.Errorf("payload = %v, want the base payload", serrOf(err).payload)
+	}
+	if SeverityOf(err) != SeverityCritical {
+		t.Errorf("SeverityOf() = %v, want %v", SeverityOf(err), SeverityCritical)
+	}
+	if OriginOf(err) != OriginRemote {
+		t.Errorf("OriginOf() = %v, want %v", OriginOf(err), OriginRemote)
+	}
+
+	serrOf(err).headers.Set("X-Order", "o-2")
+	//nolint:errcheck // type must match
+	if base.(HeaderedError).GetHTTPHeaders().Get("X-Order") != "o-1" {
+		t.Error("modifying the built Err's headers modified the base headers")
+	}
+}
+
+func TestErrBuilder_SentinelUnchanged(t *testing.T) {
+	_ = NewErrBuilder(ErrBadRequest).WithMessage("Custom").WithParam("key", "value").Build()
+
+	if ErrBadRequest.GetMessage() != "Bad request" {
+		t.Errorf("ErrBadRequest message changed to %v", ErrBadRequest.GetMessage())
+	}
+	if ErrBadRequest.GetMetadata() != nil {
+		t.Errorf("ErrBadRequest metadata changed to %v", ErrBadRequest.GetMetadata())
+	}
+}
+
+func TestErrBuilder_WithNamespace(t *testing.T) {
+	err := NewErrBuilder(ErrNotFound).WithNamespace("order").Build()
+
+	if err.GetCode() != CodeNotFound || NamespacedCode(err) != "order.NotFound" {
+		t.Errorf("GetCode(), GetNamespacedCode() = %v, %v, want NotFound, order.NotFound",
+			err.GetCode(), NamespacedCode(err))
+	}
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	if !strings.Contains(string(data), `"code":"order.NotFound"`) {
+		t.Errorf("json.Marshal() = %s, want the namespaced code", data)
+	}
+
+	// The namespace is kept by derived Errs and SetCode
+	derived := NewErrBuilder(err).WithMessage("Order not found").Build()
+	derived.SetCode("Missing")
+	if NamespacedCode(derived) != "order.Missing" {
+		t.Errorf("GetNamespacedCode() = %v, want order.Missing", NamespacedCode(derived))
+	}
+	if plain := NewErrBuilder(err).WithNamespace("").Build(); NamespacedCode(plain) != CodeNotFound {
+		t.Errorf("GetNamespacedCode() = %v, want NotFound", NamespacedCode(plain))
+	}
+}
+
+func TestErr_IsNamespaced(t *testing.T) {
+	orderNotFound := NewErrBuilder(ErrNotFound).WithNamespace("order").Build()
+	userNotFound := NewErrBuilder(ErrNotFound).WithNamespace("user").Build()
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"namespaced matches bare", orderNotFound, ErrNotFound, true},
+		{"bare matches namespaced", ErrNotFound, orderNotFound, true},
+		{"same namespace", NewErr(orderNotFound, "Order 1 not found", ""), orderNotFound, true},
+		{"raw namespaced code", orderNotFound, NewBaseErr(404, "order.NotFound", "Not found"), true},
+		{"different namespaces", orderNotFound, userNotFound, false},
+		{"different codes", orderNotFound, ErrConflict, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrBuilder_AddFieldError(t *testing.T) {
+	err := NewErrBuilder(ErrBadRequest).
+		AddFieldError("name", "required").
+		AddFieldError("age", "must be positive").
+		Build()
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	want := `{"code":"BadRequest","message":"Bad request","subErrors":[` +
+		`{"code":"InvalidInput","message":"required","field":"name","reason":"required"},` +
+		`{"code":"InvalidInput","message":"must be positive","field":"age","reason":"must be positive"}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	item, ok := As[*DetailItem](err.GetSubErrors()[0])
+	if !ok || item.Field != "name" || !errors.Is(item, ErrInvalidInput) {
+		t.Errorf("GetSubErrors()[0] = %v, want a DetailItem for name", err.GetSubErrors()[0])
+	}
+}
+
+func TestDetailItem(t *testing.T) {
+	item := NewDetailItem("age", "must be positive").WithValue(map[string]any{"raw": -1})
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	want := `{"code":"InvalidInput","message":"must be positive","field":"age","reason":"must be positive",` +
+		`"value":{"raw":-1}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	clone, _ := Clone(item).(*DetailItem)
+	clone.Value.(map[string]any)["raw"] = 1
+	if item.Value.(map[string]any)["raw"] != -1 {
+		t.Error("modifying a clone should not modify the original")
+	}
+}
@@ -0,0 +1,39 @@
+package werror
+
+import (
+	"os"
+)
+
+// Build modes returned by BuildMode.
+const (
+	BuildModeProduction = "production"
+	BuildModeDebug      = "debug"
+	BuildModeUnknown    = "unknown"
+)
+
+// BuildMode returns the build mode the service runs in, as exported by the Makefile run targets and the Dockerfile:
+// the value of the __BUILD_MODE__ environment variable if it is "production" or "debug", otherwise "production"
+// if production_mode is set, "debug" if debug_mode is set, and "unknown" if none of them is.
+func BuildMode() string {
+	switch mode := os.Getenv("__BUILD_MODE__"); mode {
+	case BuildModeProduction, BuildModeDebug:
+		return mode
+	}
+	if _, ok := os.LookupEnv("production_mode"); ok {
+		return BuildModeProduction
+	}
+	if _, ok := os.LookupEnv("debug_mode"); ok {
+		return BuildModeDebug
+	}
+	return BuildModeUnknown
+}
+
+// IsProduction reports whether the service runs in production mode, see BuildMode.
+func IsProduction() bool {
+	return BuildMode() == BuildModeProduction
+}
+
+// IsDebug reports whether the service runs in debug mode, see BuildMode.
+func IsDebug() bool {
+	return BuildMode() == BuildModeDebug
+}
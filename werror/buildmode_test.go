@@ -0,0 +1,51 @@
+package werror
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBuildMode(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"nothing set", nil, BuildModeUnknown},
+		{"__BUILD_MODE__ production", map[string]string{"__BUILD_MODE__": "production"}, BuildModeProduction},
+		{"__BUILD_MODE__ debug", map[string]string{"__BUILD_MODE__": "debug"}, BuildModeDebug},
+		{
+			"__BUILD_MODE__ wins",
+			map[string]string{"__BUILD_MODE__": "debug", "production_mode": "production"},
+			BuildModeDebug,
+		},
+		{"production_mode", map[string]string{"production_mode": ""}, BuildModeProduction},
+		{"debug_mode", map[string]string{"debug_mode": ""}, BuildModeDebug},
+		{
+			"unrecognized __BUILD_MODE__",
+			map[string]string{"__BUILD_MODE__": "staging", "debug_mode": "debug"},
+			BuildModeDebug,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"__BUILD_MODE__", "production_mode", "debug_mode"} {
+				t.Setenv(key, "")
+				_ = os.Unsetenv(key)
+			}
+			for key, val := range tt.env {
+				t.Setenv(key, val)
+			}
+
+			if got := BuildMode(); got != tt.want {
+				t.Errorf("BuildMode() = %q, want %q", got, tt.want)
+			}
+			if got := IsProduction(); got != (tt.want == BuildModeProduction) {
+				t.Errorf("IsProduction() = %v, want %v", got, tt.want == BuildModeProduction)
+			}
+			if got := IsDebug(); got != (tt.want == BuildModeDebug) {
+				t.Errorf("IsDebug() = %v, want %v", got, tt.want == BuildModeDebug)
+			}
+		})
+	}
+}
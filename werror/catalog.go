@@ -0,0 +1,115 @@
+package werror
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrorSpec describes a registered base Err for documentation, see Catalog.
+type ErrorSpec struct {
+	Code       ErrCode `json:"code"`
+	HttpStatus int     `json:"httpStatus"`
+	Message    string  `json:"message"`
+}
+
+// Catalog returns the specs of all registered base Errs, i.e. those of this package and those added by
+// RegisterBaseErr, sorted by code, e.g. to generate API documentation that never drifts from the code.
+func Catalog() []ErrorSpec {
+	code2ErrMapMu.RLock()
+	defer code2ErrMapMu.RUnlock()
+	specs := make([]ErrorSpec, 0, len(Code2ErrMap))
+	for _, code := range slices.Sorted(maps.Keys(Code2ErrMap)) {
+		err := Code2ErrMap[code]
+		specs = append(specs, ErrorSpec{Code: code, HttpStatus: err.GetHttpStatus(), Message: err.GetMessage()})
+	}
+	return specs
+}
+
+// CatalogJSON returns Catalog as a JSON array.
+func CatalogJSON() ([]byte, error) {
+	return json.Marshal(Catalog())
+}
+
+// CatalogMarkdown returns Catalog as a Markdown table with a row per base Err.
+func CatalogMarkdown() string {
+	var b strings.Builder
+	b.WriteString("| Code | HTTP status | Message |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, spec := range Catalog() {
+		b.WriteString("| " + markdownCell(string(spec.Code)) +
+			" | " + strconv.Itoa(spec.HttpStatus) +
+			" | " + markdownCell(spec.Message) + " |\n")
+	}
+	return b.String()
+}
+
+// markdownCell escapes s for a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// CatalogEntry describes a base Err in a CatalogSnapshot.
+type CatalogEntry struct {
+	HttpStatus int    `json:"httpStatus"`
+	Message    string `json:"message"`
+}
+
+// CatalogSnapshot is a serializable snapshot of the error catalog keyed by code.
+// Commit a snapshot and compare it with DiffCatalogs to catch accidental changes to error codes and statuses.
+type CatalogSnapshot map[ErrCode]CatalogEntry
+
+// CatalogChangeKind is the kind of a CatalogChange.
+type CatalogChangeKind string
+
+const (
+	CatalogAdded   CatalogChangeKind = "added"
+	CatalogRemoved CatalogChangeKind = "removed"
+	CatalogChanged CatalogChangeKind = "changed"
+)
+
+// CatalogChange is a difference between two CatalogSnapshots.
+type CatalogChange struct {
+	Code ErrCode           `json:"code"`
+	Kind CatalogChangeKind `json:"kind"`
+	// The entry before the change, nil if added.
+	Old *CatalogEntry `json:"old,omitempty"`
+	// The entry after the change, nil if removed.
+	New *CatalogEntry `json:"new,omitempty"`
+}
+
+// SnapshotCatalog returns a snapshot of Catalog, i.e. of all registered base Errs keyed by their namespaced codes,
+// so that it describes what CatalogJSON publishes.
+func SnapshotCatalog() CatalogSnapshot {
+	specs := Catalog()
+	snapshot := make(CatalogSnapshot, len(specs))
+	for _, spec := range specs {
+		snapshot[spec.Code] = CatalogEntry{HttpStatus: spec.HttpStatus, Message: spec.Message}
+	}
+	return snapshot
+}
+
+// DiffCatalogs returns the entries added, removed or changed from before to after, sorted by code.
+func DiffCatalogs(before, after CatalogSnapshot) []CatalogChange {
+	var changes []CatalogChange
+	for code, o := range before {
+		switch n, ok := after[code]; {
+		case !ok:
+			changes = append(changes, CatalogChange{Code: code, Kind: CatalogRemoved, Old: &o})
+		case n != o:
+			changes = append(changes, CatalogChange{Code: code, Kind: CatalogChanged, Old: &o, New: &n})
+		}
+	}
+	for code, n := range after {
+		if _, ok := before[code]; !ok {
+			changes = append(changes, CatalogChange{Code: code, Kind: CatalogAdded, New: &n})
+		}
+	}
+	slices.SortFunc(changes, func(a, b CatalogChange) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return changes
+}
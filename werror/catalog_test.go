@@ -0,0 +1,133 @@
+package werror
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestCatalogSnapshot fails on any change to the error catalog.
+// If the change is intended, update testdata/catalog.json accordingly.
+func TestCatalogSnapshot(t *testing.T) {
+	data, err := os.ReadFile("testdata/catalog.json")
+	if err != nil {
+		t.Fatalf("os.ReadFile() failed: %v", err)
+	}
+	var committed CatalogSnapshot
+	if err := json.Unmarshal(data, &committed); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+
+	for _, change := range DiffCatalogs(committed, SnapshotCatalog()) {
+		t.Errorf("unexpected catalog change: %s %s (old: %v, new: %v)",
+			change.Kind, change.Code, change.Old, change.New)
+	}
+}
+
+func TestSnapshotCatalog(t *testing.T) {
+	snapshot := SnapshotCatalog()
+
+	want := CatalogEntry{HttpStatus: 404, Message: "Not found"}
+	if snapshot[CodeNotFound] != want {
+		t.Errorf("snapshot[NotFound] = %v, want %v", snapshot[CodeNotFound], want)
+	}
+	if len(snapshot) != len(Catalog()) {
+		t.Errorf("len(snapshot) = %v, want %v", len(snapshot), len(Catalog()))
+	}
+
+	errOrderNotFound := NewErrBuilder(ErrSentinel(http.StatusNotFound, CodeNotFound, "Order not found")).
+		WithNamespace("order").Build()
+	if err := RegisterBaseErr(errOrderNotFound); err != nil {
+		t.Fatalf("RegisterBaseErr() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		code2ErrMapMu.Lock()
+		delete(Code2ErrMap, NamespacedCode(errOrderNotFound))
+		code2ErrMapMu.Unlock()
+	})
+	want = CatalogEntry{HttpStatus: 404, Message: "Order not found"}
+	if got := SnapshotCatalog()["order.NotFound"]; got != want {
+		t.Errorf("snapshot[order.NotFound] = %v, want the registered Err %v", got, want)
+	}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	before := CatalogSnapshot{
+		"NotFound": {HttpStatus: 404, Message: "Not found"},
+		"Conflict": {HttpStatus: 409, Message: "Conflict"},
+		"Gone":     {HttpStatus: 410, Message: "Gone"},
+	}
+	after := CatalogSnapshot{
+		"NotFound":  {HttpStatus: 404, Message: "Not found"},
+		"Conflict":  {HttpStatus: 400, Message: "Conflict"},
+		"Forbidden": {HttpStatus: 403, Message: "Forbidden"},
+	}
+
+	want := []CatalogChange{
+		{
+			Code: "Conflict",
+			Kind: CatalogChanged,
+			Old:  &CatalogEntry{HttpStatus: 409, Message: "Conflict"},
+			New:  &CatalogEntry{HttpStatus: 400, Message: "Conflict"},
+		},
+		{Code: "Forbidden", Kind: CatalogAdded, New: &CatalogEntry{HttpStatus: 403, Message: "Forbidden"}},
+		{Code: "Gone", Kind: CatalogRemoved, Old: &CatalogEntry{HttpStatus: 410, Message: "Gone"}},
+	}
+
+	if got := DiffCatalogs(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffCatalogs() = %+v, want %+v", got, want)
+	}
+	if got := DiffCatalogs(before, before); len(got) != 0 {
+		t.Errorf("DiffCatalogs(same) = %+v, want none", got)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	errOrderNotFound := ErrSentinel(http.StatusNotFound, "OrderNotFound", "Order | shipment not found")
+	registered := len(Catalog())
+	if err := RegisterBaseErr(errOrderNotFound); err != nil {
+		t.Fatalf("RegisterBaseErr() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		code2ErrMapMu.Lock()
+		delete(Code2ErrMap, errOrderNotFound.GetCode())
+		code2ErrMapMu.Unlock()
+	})
+
+	catalog := Catalog()
+	if len(catalog) != registered+1 {
+		t.Errorf("len(Catalog()) = %d, want the base Errs and the registered one", len(catalog))
+	}
+	if !slices.IsSortedFunc(catalog, func(a, b ErrorSpec) int { return cmp.Compare(a.Code, b.Code) }) {
+		t.Error("Catalog() should be sorted by code")
+	}
+	want := ErrorSpec{Code: "OrderNotFound", HttpStatus: 404, Message: "Order | shipment not found"}
+	if !slices.Contains(catalog, want) {
+		t.Errorf("Catalog() should contain %v", want)
+	}
+
+	data, err := CatalogJSON()
+	if err != nil {
+		t.Fatalf("CatalogJSON() failed: %v", err)
+	}
+	var decoded []ErrorSpec
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, catalog) {
+		t.Errorf("CatalogJSON() = %s, want Catalog() as JSON", data)
+	}
+
+	md := CatalogMarkdown()
+	if !strings.HasPrefix(md, "| Code | HTTP status | Message |\n| --- | --- | --- |\n") {
+		t.Errorf("CatalogMarkdown() should start with the table header, got %q", md)
+	}
+	if !strings.Contains(md, "| OrderNotFound | 404 | Order \\| shipment not found |\n") {
+		t.Error("CatalogMarkdown() should contain an escaped row for OrderNotFound")
+	}
+	if rows := strings.Count(md, "\n"); rows != len(catalog)+2 {
+		t.Errorf("CatalogMarkdown() has %d lines, want %d", rows, len(catalog)+2)
+	}
+}
@@ -0,0 +1,43 @@
+package werror
+
+import "reflect"
+
+// UnwrapChain returns err and all errors it wraps, through Unwrap() error and Unwrap() []error recursively,
+// ordered from the outermost to the innermost error depth-first, e.g. to dump every layer when debugging.
+// An error already in the chain, e.g. one wrapping itself, ends its branch, so cycles are safe.
+func UnwrapChain(err error) []error {
+	var chain []error
+	unwrapChain(err, map[error]bool{}, &chain)
+	return chain
+}
+
+func unwrapChain(err error, visited map[error]bool, chain *[]error) {
+	if err == nil {
+		return
+	}
+	// Only pointers can form cycles, and other errors may not be usable as map keys
+	if reflect.ValueOf(err).Kind() == reflect.Pointer {
+		if visited[err] {
+			return
+		}
+		visited[err] = true
+	}
+	*chain = append(*chain, err)
+
+	inner, joined := unwrapOnce(err)
+	unwrapChain(inner, visited, chain)
+	for _, e := range joined {
+		unwrapChain(e, visited, chain)
+	}
+}
+
+// FilterErrs returns the Errs in chain, e.g. returned by UnwrapChain, in order.
+func FilterErrs(chain []error) []Err {
+	var errs []Err
+	for _, err := range chain {
+		if werr, ok := err.(Err); ok {
+			errs = append(errs, werr)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// cyclicErr wraps another error, which may be itself.
+type cyclicErr struct {
+	next error
+}
+
+func (e *cyclicErr) Error() string { return "cyclic" }
+
+func (e *cyclicErr) Unwrap() error { return e.next }
+
+func TestUnwrapChain(t *testing.T) {
+	root := errors.New("connection refused")
+	wrapped := NewErrFromError(ErrServiceUnavailable, root)
+	outer := fmt.Errorf("load user: %w", wrapped)
+	joinedA, joinedB := errors.New("a"), errors.New("b")
+	joined := errors.Join(joinedA, joinedB)
+
+	self := &cyclicErr{}
+	self.next = self
+	first := &cyclicErr{}
+	second := &cyclicErr{next: first}
+	first.next = second
+
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{"nil", nil, nil},
+		{"wrapped Err", outer, []error{outer, wrapped, root}},
+		{"joined", joined, []error{joined, joinedA, joinedB}},
+		{"self-wrapping", self, []error{self}},
+		{"indirect cycle", first, []error{first, second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnwrapChain(tt.err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("UnwrapChain() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("UnwrapChain()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterErrs(t *testing.T) {
+	inner := NewErr(ErrInvalidInput, "Name is required", "")
+	outer := NewErrFromError(ErrBadRequest, fmt.Errorf("validate: %w", inner))
+	chain := UnwrapChain(fmt.Errorf("handler: %w", outer))
+
+	got := FilterErrs(chain)
+	// inner wraps its base ErrInvalidInput
+	if len(got) != 3 || got[0] != outer || got[1] != inner || got[2] != ErrInvalidInput {
+		t.Errorf("FilterErrs() = %v, want [%v %v %v]", got, outer, inner, ErrInvalidInput)
+	}
+	if FilterErrs(nil) != nil {
+		t.Error("FilterErrs(nil) should return nil")
+	}
+}
@@ -0,0 +1,51 @@
+// Package chi integrates werror with the chi router and its render package.
+package chi
+
+import (
+	"encoding/json"
+	h "net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// Renderer is a render.Renderer writing an Err with its HTTP status, its HTTP headers if it is a
+// werror.HeaderedError, and its JSON as the body.
+type Renderer struct {
+	werror.Err
+}
+
+// Render sets the response status with render.Status, and the Err's HTTP headers.
+func (rr Renderer) Render(w h.ResponseWriter, r *h.Request) error {
+	if herr, ok := rr.Err.(werror.HeaderedError); ok {
+		for key, values := range herr.GetHTTPHeaders() {
+			w.Header()[key] = values
+		}
+	}
+	status := rr.GetHttpStatus()
+	if status == 0 {
+		status = h.StatusInternalServerError
+	}
+	render.Status(r, status)
+	return nil
+}
+
+// MarshalJSON marshals the Err, the Renderer itself is not part of the body.
+func (rr Renderer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rr.Err)
+}
+
+// Render writes err with werror.WriteError, so errors are written by chi handlers exactly like by any other.
+func Render(w h.ResponseWriter, r *h.Request, err error) {
+	werror.WriteError(w, r, err)
+}
+
+// ErrHandlerFunc converts fn to an http.HandlerFunc rendering the error fn returns, if any, with Render.
+func ErrHandlerFunc(fn func(w h.ResponseWriter, r *h.Request) error) h.HandlerFunc {
+	return func(w h.ResponseWriter, r *h.Request) {
+		if err := fn(w, r); err != nil {
+			Render(w, r, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ch "github.com/go-chi/chi/v5"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func TestErrHandlerFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "Err", err: werror.ErrConflict, wantStatus: http.StatusConflict, wantCode: "Conflict"},
+		{
+			name:       "Wrapped Err",
+			err:        werror.NewErr(werror.ErrNotFound, "User not found", ""),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+		},
+		{
+			name:       "Standard error",
+			err:        errors.New("database connection failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+		{
+			name:       "Error mapped by werror.DefaultMapper",
+			err:        fmt.Errorf("query: %w", context.Canceled),
+			wantStatus: werror.StatusClientClosedRequest,
+			wantCode:   "ClientClosedRequest",
+		},
+		{name: "No error", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ch.NewRouter()
+			r.Get("/", ErrHandlerFunc(func(http.ResponseWriter, *http.Request) error { return tt.err }))
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.err == nil {
+				return
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v, body %s", err, rec.Body)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("code = %v, want %v", body["code"], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRender_Headers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Render(rec, httptest.NewRequest(http.MethodGet, "/", nil),
+		werror.NewUnauthorizedChallenge("Bearer", "api", "invalid_token"))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec.Header().Get("WWW-Authenticate") == "" {
+		t.Error("WWW-Authenticate header not set")
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/daotl/go-web-common/werror/chi
+
+go 1.25
+
+require (
+	github.com/daotl/go-web-common v0.0.0-20261016035731-20c2196dff7d
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/go-chi/render v1.0.3
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/ajg/form v1.5.1 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.6.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+)
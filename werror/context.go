@@ -0,0 +1,36 @@
+package werror
+
+import (
+	"context"
+	"errors"
+)
+
+// contextErrBases maps the errors of done contexts to base Errs. It is the one mapping shared by
+// ErrFromContext, DefaultMapper and FromNetError, so a context error becomes the same Err whichever converts it.
+var contextErrBases = []struct {
+	sentinel error
+	base     Err
+}{
+	{context.DeadlineExceeded, ErrTimeout},
+	{context.Canceled, ErrClientClosedRequest},
+}
+
+// ErrFromContext returns an Err for why ctx is done: ErrTimeout if its deadline was exceeded,
+// ErrClientClosedRequest if it was canceled. It returns nil if ctx is not done.
+func ErrFromContext(ctx context.Context) Err {
+	return fromContextErr(ctx.Err())
+}
+
+// fromContextErr creates an Err from err if it wraps context.DeadlineExceeded or context.Canceled,
+// otherwise it returns nil.
+func fromContextErr(err error) Err {
+	if err == nil {
+		return nil
+	}
+	for _, mapping := range contextErrBases {
+		if errors.Is(err, mapping.sentinel) {
+			return NewErrFromError(mapping.base, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package werror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrFromContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want Err
+	}{
+		{"not done", context.Background(), nil},
+		{"deadline exceeded", expired, ErrTimeout},
+		{"canceled", canceled, ErrClientClosedRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrFromContext(tt.ctx)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ErrFromContext() = %v, want nil", got)
+				}
+				return
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("ErrFromContext() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(got, tt.ctx.Err()) {
+				t.Errorf("ErrFromContext() = %v, should wrap %v", got, tt.ctx.Err())
+			}
+		})
+	}
+}
+
+func TestToErr_Context(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      error
+		wantCode   ErrCode
+		wantStatus int
+	}{
+		{"DeadlineExceeded", context.DeadlineExceeded, CodeTimeout, 408},
+		{"Canceled", context.Canceled, CodeClientClosedRequest, StatusClientClosedRequest},
+		{"wrapped DeadlineExceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), CodeTimeout, 408},
+		{"plain error", errors.New("boom"), CodeInternalServerError, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToErr(tt.input)
+			if got.GetCode() != tt.wantCode || got.GetHttpStatus() != tt.wantStatus {
+				t.Errorf("ToErr() = %v, want code %s and status %d", got, tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestContextErrMapping_Shared(t *testing.T) {
+	for _, err := range []error{context.DeadlineExceeded, fmt.Errorf("query: %w", context.Canceled)} {
+		fromCtx, fromMapper, fromNet := fromContextErr(err), ToErr(err), FromNetError(err)
+		if fromCtx.GetCode() != fromMapper.GetCode() || fromCtx.GetCode() != fromNet.GetCode() {
+			t.Errorf("%v: ErrFromContext, DefaultMapper and FromNetError codes = %v, %v, %v, want them equal",
+				err, fromCtx.GetCode(), fromMapper.GetCode(), fromNet.GetCode())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package werror
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CopyOption modifies the copy created by CopyErr.
+type CopyOption func(*Serr)
+
+// WithMessage overrides the message of the copy, blank messages are ignored.
+func WithMessage(msg string) CopyOption {
+	return func(e *Serr) {
+		if msg = strings.TrimSpace(msg); msg != "" {
+			e.SetMessage(msg)
+		}
+	}
+}
+
+// WithCode overrides the code of the copy keeping its namespace, blank codes are ignored.
+func WithCode(code ErrCode) CopyOption {
+	return func(e *Serr) {
+		if strings.TrimSpace(string(code)) != "" {
+			e.SetCode(code)
+		}
+	}
+}
+
+// WithDetails replaces the sub-errors of the copy with errs.
+func WithDetails(errs ...Err) CopyOption {
+	return func(e *Serr) {
+		e.SetSubErrors(slices.Clone(errs))
+	}
+}
+
+// WithParams replaces the Metadata of the copy with a deep copy of params.
+func WithParams(params map[string]any) CopyOption {
+	return func(e *Serr) {
+		e.SetMetadata(cloneValue(params))
+	}
+}
+
+// CopyErr creates a deep copy of base modified by opts, which is safe to customize even if base is a sentinel.
+// The copy shares no sub-errors slice or Metadata with base, and errors.Is(copy, base) holds unless the code is
+// overridden.
+func CopyErr(base Err, opts ...CopyOption) Err {
+	c, serr := cloneSerr(base)
+	code, msg := serr.Code, serr.Message
+	for _, opt := range opts {
+		opt(serr)
+	}
+	if serr.Code != code || serr.Message != msg {
+		serr.error = fmt.Errorf("%w: %s %s", base, serr.Code, serr.Message)
+	}
+	return c
+}
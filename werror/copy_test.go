@@ -0,0 +1,61 @@
+package werror
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCopyErr(t *testing.T) {
+	detail := NewErr(ErrInvalidInput, "", "name is required")
+	copied := CopyErr(ErrBadRequest,
+		WithMessage("Invalid order"),
+		WithDetails(detail),
+		WithParams(map[string]any{"orderId": "42"}),
+	)
+
+	if copied.GetMessage() != "Invalid order" || copied.GetCode() != CodeBadRequest {
+		t.Errorf("CopyErr() = %v %q, want BadRequest %q", copied.GetCode(), copied.GetMessage(), "Invalid order")
+	}
+	if !errors.Is(copied, ErrBadRequest) {
+		t.Error("errors.Is(copy, ErrBadRequest) = false, want true")
+	}
+	if !reflect.DeepEqual(copied.GetMetadata(), map[string]any{"orderId": "42"}) {
+		t.Errorf("CopyErr() Metadata = %v", copied.GetMetadata())
+	}
+	if ErrBadRequest.GetMessage() != "Bad request" || ErrBadRequest.GetSubErrors() != nil {
+		t.Error("CopyErr() modified the sentinel")
+	}
+
+	recoded := CopyErr(NewErrBuilder(ErrNotFound).WithNamespace("order").Build(), WithCode(CodeResourceNotFound))
+	if NamespacedCode(recoded) != "order."+CodeResourceNotFound {
+		t.Errorf("WithCode() namespaced code = %v, want order.%v", NamespacedCode(recoded), CodeResourceNotFound)
+	}
+}
+
+func TestCopyErr_NoAliasing(t *testing.T) {
+	base := NewErr(ErrBadRequest, "", "")
+	first := NewErr(ErrInvalidInput, "", "a")
+	base.AddSubErrors(first, NewErr(ErrInvalidInput, "", "b"))
+	base.SetMetadata(map[string]any{"tags": []any{"x"}})
+	base.(*Serr).Freeze()
+
+	copied := CopyErr(base)
+	copied.GetSubErrors()[0] = ErrConflict
+	copied.AddSubErrors(ErrConflict)
+	copied.GetMetadata().(map[string]any)["tags"].([]any)[0] = "y"
+
+	if subs := base.GetSubErrors(); len(subs) != 2 || subs[0] != first {
+		t.Errorf("base sub-errors changed through copy: %v", subs)
+	}
+	if tag := base.GetMetadata().(map[string]any)["tags"].([]any)[0]; tag != "x" {
+		t.Errorf("base Metadata changed through copy: %v", tag)
+	}
+
+	details := []Err{ErrConflict}
+	withDetails := CopyErr(base, WithDetails(details...))
+	details[0] = ErrNotFound
+	if withDetails.GetSubErrors()[0] != ErrConflict {
+		t.Error("WithDetails() aliases the caller's slice")
+	}
+}
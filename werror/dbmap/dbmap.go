@@ -0,0 +1,80 @@
+// Package dbmap provides werror.ErrCodeMappers for database driver errors.
+package dbmap
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// sqlStateErr is implemented by the error types of PostgreSQL drivers, e.g. *pgconn.PgError and *pq.Error.
+type sqlStateErr interface {
+	error
+	SQLState() string
+}
+
+// PostgresMapper returns a mapper for PostgreSQL errors by SQLSTATE:
+// unique, foreign key and serialization violations map to werror.ErrConflict,
+// not-null and check violations to werror.ErrInvalidInput, query cancellations to werror.ErrTimeout
+// and too many connections to werror.ErrServiceUnavailable.
+func PostgresMapper() *werror.ErrCodeMapper {
+	m := &werror.ErrCodeMapper{}
+	for _, mapping := range []struct {
+		state  string
+		target werror.Err
+	}{
+		{"23505", werror.ErrConflict},           // unique_violation
+		{"23503", werror.ErrConflict},           // foreign_key_violation
+		{"40001", werror.ErrConflict},           // serialization_failure
+		{"40P01", werror.ErrConflict},           // deadlock_detected
+		{"23502", werror.ErrInvalidInput},       // not_null_violation
+		{"23514", werror.ErrInvalidInput},       // check_violation
+		{"22001", werror.ErrInvalidInput},       // string_data_right_truncation
+		{"57014", werror.ErrTimeout},            // query_canceled
+		{"53300", werror.ErrServiceUnavailable}, // too_many_connections
+	} {
+		m.Register(sqlState(mapping.state), mapping.target)
+	}
+	return m
+}
+
+// MySQLMapper returns a mapper for *mysql.MySQLError by error number:
+// duplicate entries, foreign key violations and deadlocks map to werror.ErrConflict,
+// null columns to werror.ErrInvalidInput, lock wait timeouts to werror.ErrTimeout
+// and too many connections to werror.ErrServiceUnavailable.
+func MySQLMapper() *werror.ErrCodeMapper {
+	m := &werror.ErrCodeMapper{}
+	for _, mapping := range []struct {
+		number uint16
+		target werror.Err
+	}{
+		{1062, werror.ErrConflict},           // ER_DUP_ENTRY
+		{1451, werror.ErrConflict},           // ER_ROW_IS_REFERENCED_2
+		{1452, werror.ErrConflict},           // ER_NO_REFERENCED_ROW_2
+		{1213, werror.ErrConflict},           // ER_LOCK_DEADLOCK
+		{1048, werror.ErrInvalidInput},       // ER_BAD_NULL_ERROR
+		{1205, werror.ErrTimeout},            // ER_LOCK_WAIT_TIMEOUT
+		{1040, werror.ErrServiceUnavailable}, // ER_CON_COUNT_ERROR
+	} {
+		m.Register(mysqlNumber(mapping.number), mapping.target)
+	}
+	return m
+}
+
+// sqlState returns a predicate matching errors wrapping a PostgreSQL error with the given SQLSTATE.
+func sqlState(state string) func(error) bool {
+	return func(err error) bool {
+		var serr sqlStateErr
+		return errors.As(err, &serr) && serr.SQLState() == state
+	}
+}
+
+// mysqlNumber returns a predicate matching errors wrapping a *mysql.MySQLError with the given number.
+func mysqlNumber(number uint16) func(error) bool {
+	return func(err error) bool {
+		var merr *mysql.MySQLError
+		return errors.As(err, &merr) && merr.Number == number
+	}
+}
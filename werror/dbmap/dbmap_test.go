@@ -0,0 +1,77 @@
+package dbmap
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// pgError mocks *pgconn.PgError and *pq.Error.
+type pgError struct {
+	Code string
+}
+
+func (e *pgError) Error() string {
+	return "pg error " + e.Code
+}
+
+func (e *pgError) SQLState() string {
+	return e.Code
+}
+
+func TestPostgresMapper(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want werror.ErrCode
+	}{
+		{"unique violation", &pgError{Code: "23505"}, werror.CodeConflict},
+		{"wrapped not null violation", fmt.Errorf("insert: %w", &pgError{Code: "23502"}), werror.CodeInvalidInput},
+		{"query canceled", &pgError{Code: "57014"}, werror.CodeTimeout},
+		{"unknown state", &pgError{Code: "XX000"}, werror.CodeInternalError},
+		{"not a driver error", sql.ErrConnDone, werror.CodeInternalError},
+	}
+	m := PostgresMapper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Map(tt.err)
+			if got.GetCode() != tt.want {
+				t.Errorf("Map() = %v, want code %v", got, tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Map() = %v, should wrap %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestMySQLMapper(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want werror.ErrCode
+	}{
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, werror.CodeConflict},
+		{"wrapped deadlock", fmt.Errorf("update: %w", &mysql.MySQLError{Number: 1213}), werror.CodeConflict},
+		{"bad null", &mysql.MySQLError{Number: 1048}, werror.CodeInvalidInput},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, werror.CodeTimeout},
+		{"unknown number", &mysql.MySQLError{Number: 1}, werror.CodeInternalError},
+		{"postgres error", &pgError{Code: "23505"}, werror.CodeInternalError},
+	}
+	m := MySQLMapper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Map(tt.err); got.GetCode() != tt.want {
+				t.Errorf("Map() = %v, want code %v", got, tt.want)
+			}
+		})
+	}
+	if got := m.Map(nil); got != nil {
+		t.Errorf("Map(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/daotl/go-web-common/werror/dbmap
+
+go 1.25
+
+require (
+	github.com/daotl/go-web-common v0.0.0-20261016035731-20c2196dff7d
+	github.com/go-sql-driver/mysql v1.9.3
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.6.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+)
@@ -0,0 +1,34 @@
+package werror
+
+// DefaultDetailChanLimit is the maximum number of sub-errors kept by NewErrFromDetailChan.
+const DefaultDetailChanLimit = 1000
+
+// NewErrFromDetailChan creates a new Err from base with the Errs received from ch as sub-errors,
+// so that details produced incrementally, e.g. while validating a large dataset, need not be buffered.
+// It returns once ch is closed. At most DefaultDetailChanLimit sub-errors are kept, see NewErrFromDetailChanN.
+func NewErrFromDetailChan(base Err, ch <-chan Err) Err {
+	return NewErrFromDetailChanN(base, ch, DefaultDetailChanLimit)
+}
+
+// NewErrFromDetailChanN is like NewErrFromDetailChan, but keeps at most limit sub-errors.
+// The channel is still drained until closed so that the producer never blocks,
+// and the number of dropped sub-errors is recorded in Metadata as "droppedDetails".
+func NewErrFromDetailChanN(base Err, ch <-chan Err, limit int) Err {
+	err := NewErr(base, "", "")
+	var details []Err
+	dropped := 0
+	for detail := range ch {
+		switch {
+		case detail == nil:
+		case len(details) < limit:
+			details = append(details, detail)
+		default:
+			dropped++
+		}
+	}
+	err.SetSubErrors(details)
+	if dropped > 0 {
+		err.SetMetadata(map[string]any{"droppedDetails": dropped})
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package werror
+
+import (
+	"fmt"
+	"testing"
+)
+
+func produceDetails(n int) <-chan Err {
+	ch := make(chan Err)
+	go func() {
+		defer close(ch)
+		for i := range n {
+			ch <- NewErr(ErrInvalidInput, fmt.Sprintf("Row %d is invalid", i), "")
+		}
+		ch <- nil
+	}()
+	return ch
+}
+
+func TestNewErrFromDetailChan(t *testing.T) {
+	err := NewErrFromDetailChan(ErrBadRequest, produceDetails(3))
+
+	if err.GetCode() != CodeBadRequest {
+		t.Errorf("GetCode() = %v, want %v", err.GetCode(), CodeBadRequest)
+	}
+	subs := err.GetSubErrors()
+	if len(subs) != 3 {
+		t.Fatalf("GetSubErrors() = %v, want 3 sub-errors", subs)
+	}
+	for i, sub := range subs {
+		if want := fmt.Sprintf("Row %d is invalid", i); sub.GetMessage() != want {
+			t.Errorf("sub-error %d = %q, want %q", i, sub.GetMessage(), want)
+		}
+	}
+	if err.GetMetadata() != nil {
+		t.Errorf("GetMetadata() = %v, want nil", err.GetMetadata())
+	}
+}
+
+func TestNewErrFromDetailChanN(t *testing.T) {
+	err := NewErrFromDetailChanN(ErrBadRequest, produceDetails(5), 2)
+
+	if len(err.GetSubErrors()) != 2 {
+		t.Errorf("GetSubErrors() = %v, want 2 sub-errors", err.GetSubErrors())
+	}
+	meta, _ := err.GetMetadata().(map[string]any)
+	if meta["droppedDetails"] != 3 {
+		t.Errorf("GetMetadata() = %v, want droppedDetails 3", err.GetMetadata())
+	}
+}
@@ -0,0 +1,66 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DetailItem is an ErrInvalidInput about a single field of the request, to be used as a sub-error,
+// e.g. with ErrBuilder.AddFieldError, so clients get the field and the reason in a structured form.
+type DetailItem struct { //nolint:errname // lib
+	*Serr
+
+	Field  string `json:"field"           dc:"Invalid field"`
+	Reason string `json:"reason"          dc:"Why the field is invalid"`
+	Value  any    `json:"value,omitempty" dc:"Received value of the field"`
+}
+
+// NewDetailItem creates a DetailItem for field with reason as message.
+func NewDetailItem(field, reason string) *DetailItem {
+	err := &DetailItem{
+		Serr: &Serr{
+			error:      fmt.Errorf("%w: %s: %s", ErrInvalidInput, field, reason),
+			HttpStatus: ErrInvalidInput.GetHttpStatus(),
+			Code:       ErrInvalidInput.GetCode(),
+			Message:    reason,
+		},
+		Field:  field,
+		Reason: reason,
+	}
+	observe(err)
+	return err
+}
+
+// WithValue sets the received value of the field and returns the DetailItem.
+func (e *DetailItem) WithValue(v any) *DetailItem {
+	e.Value = v
+	return e
+}
+
+// Clone returns a mutable copy of the DetailItem.
+func (e *DetailItem) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *DetailItem) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	c.Value = cloneValue(e.Value)
+	return &c
+}
+
+// MarshalJSON serializes the DetailItem like Serr.MarshalJSON, with the field, reason and value.
+func (e *DetailItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *DetailItem) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+		Value  any    `json:"value,omitempty"`
+	}{e.toJSON(ancestors), e.Field, e.Reason, e.Value}
+}
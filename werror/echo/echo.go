@@ -0,0 +1,47 @@
+// Package echo integrates werror with the Echo web framework.
+package echo
+
+import (
+	"errors"
+	h "net/http"
+
+	ec "github.com/labstack/echo/v4"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// HTTPErrorHandler is an echo.HTTPErrorHandler that writes err as a werror JSON response.
+// An *echo.HTTPError is mapped to a base Err by its status code with werror.StatusToErr,
+// or to werror.ErrInternalServerError if its status code is not an error status.
+// Nothing is written if the response has already been committed.
+func HTTPErrorHandler(err error, c ec.Context) {
+	if c.Response().Committed {
+		return
+	}
+	werror.WriteError(c.Response(), c.Request(), toErr(err))
+}
+
+// RegisterErrorHandler makes e handle the errors returned by its handlers and middlewares with HTTPErrorHandler.
+func RegisterErrorHandler(e *ec.Echo) {
+	e.HTTPErrorHandler = HTTPErrorHandler
+}
+
+func toErr(err error) error {
+	var werr werror.Err
+	if errors.As(err, &werr) {
+		return werr
+	}
+
+	var he *ec.HTTPError
+	if !errors.As(err, &he) {
+		return err
+	}
+	base := werror.StatusToErr(he.Code)
+	if base == nil {
+		base = werror.ErrInternalServerError
+	}
+	if msg, ok := he.Message.(string); ok && base.GetHttpStatus() < h.StatusInternalServerError {
+		return werror.NewErr(base, msg, "")
+	}
+	return base
+}
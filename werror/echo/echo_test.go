@@ -0,0 +1,146 @@
+package echo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ec "github.com/labstack/echo/v4"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "Err", err: werror.ErrConflict, wantStatus: http.StatusConflict, wantCode: "Conflict"},
+		{
+			name:       "Echo HTTPError",
+			err:        ec.NewHTTPError(http.StatusNotFound, "Not Found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+		},
+		{
+			name:       "Echo HTTPError with unmapped status",
+			err:        ec.NewHTTPError(http.StatusTeapot),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BadRequest",
+		},
+		{
+			name:       "Echo HTTPError with unmapped 5xx status",
+			err:        ec.NewHTTPError(http.StatusLoopDetected),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+		{
+			name:       "Echo HTTPError with non-error status",
+			err:        ec.NewHTTPError(http.StatusFound, "Moved"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+		{
+			name:       "Standard error",
+			err:        errors.New("database connection failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ec.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			HTTPErrorHandler(tt.err, c)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("json.Unmarshal() failed: %v", err)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("body code = %v, want %v", body["code"], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHTTPErrorHandler_Committed(t *testing.T) {
+	e := ec.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	_ = c.String(http.StatusOK, "done")
+
+	HTTPErrorHandler(werror.ErrConflict, c)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "done" {
+		t.Errorf("committed response changed to %v %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRegisterErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "Err",
+			err:        werror.ErrResourceAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   "ResourceAlreadyExists",
+		},
+		{
+			name:       "Wrapped Err",
+			err:        fmt.Errorf("creating user: %w", werror.ErrForbidden),
+			wantStatus: http.StatusForbidden,
+			wantCode:   "Forbidden",
+		},
+		{
+			name:       "Echo HTTPError",
+			err:        ec.NewHTTPError(http.StatusRequestEntityTooLarge, "too large"),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "RequestEntityTooLarge",
+		},
+		{
+			name:       "Standard error",
+			err:        errors.New("database connection failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ec.New()
+			RegisterErrorHandler(e)
+			e.POST("/users", func(ec.Context) error { return tt.err })
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("json.Unmarshal() failed: %v", err)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("body code = %v, want %v", body["code"], tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/daotl/go-web-common/werror/echo
+
+go 1.25
+
+require (
+	github.com/daotl/go-web-common v0.0.0-20261016035731-20c2196dff7d
+	github.com/labstack/echo/v4 v4.13.4
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.6.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+)
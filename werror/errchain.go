@@ -0,0 +1,88 @@
+package werror
+
+import (
+	"encoding/json"
+	h "net/http"
+)
+
+// causeChain is a link of an ErrChain: an Err wrapping the next Err of the chain as its cause.
+// It reports the status, code, message etc. of its Err, while errors.Is and errors.As
+// also search the rest of the chain.
+type causeChain struct { //nolint:errname // lib
+	Err
+
+	next Err
+}
+
+// ErrChain links errs into a cause chain where each Err wraps the next one, e.g.
+// ErrChain(ErrBadRequest, ErrNotFound, ErrInternalError). The chain reports the HTTP status, code and message
+// of the outermost (first) Err, while errors.Is and errors.As search the full chain. nil Errs are skipped,
+// and ErrChain returns nil if there are no Errs, or the Err itself if there is only one. Use ErrChainOf
+// to get the Errs of a chain back.
+func ErrChain(errs ...Err) Err {
+	var chain Err
+	for i := len(errs) - 1; i >= 0; i-- {
+		switch {
+		case errs[i] == nil:
+		case chain == nil:
+			chain = errs[i]
+		default:
+			chain = &causeChain{Err: errs[i], next: chain}
+		}
+	}
+	return chain
+}
+
+// ErrChainOf returns the Errs of a chain created by ErrChain from the outermost to the innermost,
+// or just err if it is not a chain.
+func ErrChainOf(err Err) []Err {
+	if err == nil {
+		return nil
+	}
+	var errs []Err
+	for {
+		c, ok := err.(*causeChain)
+		if !ok {
+			return append(errs, err)
+		}
+		errs = append(errs, c.Err)
+		err = c.next
+	}
+}
+
+func (c *causeChain) Error() string {
+	return c.Err.Error() + ": " + c.next.Error()
+}
+
+// Is reports false, errors.Is then checks the Errs of the chain in order through Unwrap.
+func (c *causeChain) Is(error) bool {
+	return false
+}
+
+// As reports false, errors.As then checks the Errs of the chain in order through Unwrap.
+func (c *causeChain) As(any) bool {
+	return false
+}
+
+// Unwrap returns the Err of the link and the rest of the chain.
+func (c *causeChain) Unwrap() []error {
+	return []error{c.Err, c.next}
+}
+
+// Clone returns a deep, mutable copy of the chain.
+func (c *causeChain) Clone() Err {
+	return &causeChain{Err: Clone(c.Err), next: Clone(c.next)}
+}
+
+// GetHTTPHeaders returns the headers of the outermost Err, see HeaderedError.
+func (c *causeChain) GetHTTPHeaders() h.Header {
+	if herr, ok := c.Err.(HeaderedError); ok {
+		return herr.GetHTTPHeaders()
+	}
+	return nil
+}
+
+// MarshalJSON serializes the chain as its outermost Err.
+func (c *causeChain) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Err)
+}
@@ -0,0 +1,88 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrChain(t *testing.T) {
+	chain := ErrChain(ErrBadRequest, ErrNotFound, ErrInternalError)
+
+	if chain.GetHttpStatus() != http.StatusBadRequest || chain.GetCode() != CodeBadRequest {
+		t.Errorf("ErrChain() = %v %v, want %v %v",
+			chain.GetHttpStatus(), chain.GetCode(), http.StatusBadRequest, CodeBadRequest)
+	}
+	for _, target := range []Err{ErrBadRequest, ErrNotFound, ErrInternalError} {
+		if !errors.Is(chain, target) {
+			t.Errorf("errors.Is(chain, %v) = false, want true", target.GetCode())
+		}
+	}
+	if errors.Is(chain, ErrConflict) {
+		t.Error("errors.Is(chain, ErrConflict) = true, want false")
+	}
+	if !IsErrOf(chain, CodeNotFound) {
+		t.Error("IsErrOf(chain, NotFound) = false, want true")
+	}
+
+	var werr Err
+	if !errors.As(chain, &werr) || werr != chain {
+		t.Error("errors.As(chain, &Err) should find the chain itself")
+	}
+	var serr *Serr
+	if !errors.As(chain, &serr) || serr != ErrBadRequest {
+		t.Errorf("errors.As(chain, &*Serr) = %v, want the outermost Err", serr)
+	}
+
+	data, err := json.Marshal(chain)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	if want := `{"code":"BadRequest","message":"Bad request"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestErrChainOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Err
+		want []Err
+	}{
+		{"nil", nil, nil},
+		{"single Err", ErrNotFound, []Err{ErrNotFound}},
+		{
+			"chain",
+			ErrChain(ErrBadRequest, nil, ErrNotFound, ErrInternalError),
+			[]Err{ErrBadRequest, ErrNotFound, ErrInternalError},
+		},
+		{"empty chain", ErrChain(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrChainOf(tt.err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ErrChainOf() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ErrChainOf()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestErrChain_Clone(t *testing.T) {
+	chain := ErrChain(ErrBadRequest, ErrNotFound)
+	clone := Clone(chain)
+	clone.SetMessage("Changed")
+
+	if chain.GetMessage() != "Bad request" {
+		t.Errorf("Clone() should not modify the original, got %q", chain.GetMessage())
+	}
+	if errs := ErrChainOf(clone); len(errs) != 2 || errs[1].GetCode() != CodeNotFound {
+		t.Errorf("ErrChainOf(clone) = %v, want 2 Errs", errs)
+	}
+}
@@ -0,0 +1,62 @@
+package werror
+
+import (
+	"context"
+	h "net/http"
+	"sync"
+)
+
+// errSlotKey is the context key of the errSlot installed by NormalizeMiddleware.
+type errSlotKey struct{}
+
+// errSlot holds the error stored by a handler for NormalizeMiddleware.
+type errSlot struct {
+	mu  sync.Mutex
+	err error
+}
+
+// StoreErr stores werr in ctx for NormalizeMiddleware to write, see StoreAnyErr.
+func StoreErr(ctx context.Context, werr Err) {
+	StoreAnyErr(ctx, werr)
+}
+
+// StoreAnyErr stores err, which need not be an Err, in ctx for NormalizeMiddleware to write,
+// replacing any error stored before. It does nothing if ctx is not the context of a request
+// handled by NormalizeMiddleware.
+func StoreAnyErr(ctx context.Context, err error) {
+	if slot, ok := ctx.Value(errSlotKey{}).(*errSlot); ok {
+		slot.mu.Lock()
+		slot.err = err
+		slot.mu.Unlock()
+	}
+}
+
+// LoadErr returns the error stored in ctx with StoreErr or StoreAnyErr, nil if there is none.
+func LoadErr(ctx context.Context) error {
+	slot, ok := ctx.Value(errSlotKey{}).(*errSlot)
+	if !ok {
+		return nil
+	}
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	return slot.err
+}
+
+// NormalizeMiddleware returns a handler that lets next report errors with StoreErr or StoreAnyErr on the request
+// context instead of writing them. After next returns, the stored error, converted with ToErr if it is not
+// an Err, e.g. a plain error returned by a library, is stored back and written with WriteError.
+// Nothing is written if no error was stored.
+func NormalizeMiddleware(next h.Handler) h.Handler {
+	return h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		ctx := context.WithValue(r.Context(), errSlotKey{}, &errSlot{})
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		err := LoadErr(ctx)
+		if err == nil {
+			return
+		}
+		werr := ToErr(err)
+		StoreErr(ctx, werr)
+		WriteError(w, r, werr)
+	})
+}
@@ -0,0 +1,68 @@
+package werror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   ErrCode
+	}{
+		{"plain error", errors.New("chi: route not found"), http.StatusInternalServerError, CodeInternalServerError},
+		{"Err", ErrNotFound, http.StatusNotFound, CodeNotFound},
+		{"nil error", nil, http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqCtx context.Context
+			handler := NormalizeMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqCtx = r.Context()
+				if tt.err == nil {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				StoreAnyErr(r.Context(), tt.err)
+			}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				if rec.Body.Len() != 0 {
+					t.Errorf("body = %s, want nothing written", rec.Body)
+				}
+				return
+			}
+			var body struct {
+				Code ErrCode `json:"code"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("json.Unmarshal() failed: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v", body.Code, tt.wantCode)
+			}
+			if stored, ok := LoadErr(reqCtx).(Err); !ok || stored.GetCode() != tt.wantCode {
+				t.Errorf("stored error = %v, want it normalized to an Err", LoadErr(reqCtx))
+			}
+		})
+	}
+}
+
+func TestStoreErr_WithoutMiddleware(t *testing.T) {
+	ctx := context.Background()
+	StoreErr(ctx, ErrNotFound)
+	if err := LoadErr(ctx); err != nil {
+		t.Errorf("LoadErr() = %v, want nil outside NormalizeMiddleware", err)
+	}
+}
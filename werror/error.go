@@ -3,7 +3,10 @@ package werror
 import (
 	"errors"
 	"fmt"
+	"maps"
 	h "net/http"
+	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -36,8 +39,9 @@ type Err interface {
 	Is(error) bool
 	As(any) bool
 	GetHttpStatus() int
-	GetCode() string
-	SetCode(code string)
+	// GetCode returns the code without namespace
+	GetCode() ErrCode
+	SetCode(code ErrCode)
 	GetMessage() string
 	SetMessage(msg string)
 	GetSubErrors() []Err
@@ -48,6 +52,104 @@ type Err interface {
 	SetMetadata(meta any)
 }
 
+// The optional interfaces below are implemented by Serr and the types embedding it.
+// Other Err implementations may implement them, the functions using them fall back to defaults otherwise.
+
+// Namespaced is implemented by Errs with a namespaced code, see NamespacedCode.
+type Namespaced interface {
+	// GetNamespacedCode returns the code with namespace, e.g. "order.NotFound", as written in JSON
+	GetNamespacedCode() ErrCode
+}
+
+// Originated is implemented by Errs knowing where they were created, see OriginOf.
+type Originated interface {
+	// GetOrigin tells whether the Err was created locally or received from a downstream service
+	GetOrigin() Origin
+}
+
+// Cloner is implemented by Errs that can be copied, see Clone.
+type Cloner interface {
+	// Clone returns a deep, mutable copy of the Err
+	Clone() Err
+}
+
+// Freezer is implemented by Errs that can be made read-only, see ErrSentinel.
+type Freezer interface {
+	// Freeze makes the Err read-only, its SetXxx/AddXxx methods then panic
+	// (or do nothing in builds with the werror_release tag)
+	Freeze()
+	IsFrozen() bool
+}
+
+// NamespacedCode returns the namespaced code of err if it implements Namespaced, otherwise its code.
+func NamespacedCode(err Err) ErrCode {
+	if n, ok := err.(Namespaced); ok {
+		return n.GetNamespacedCode()
+	}
+	return err.GetCode()
+}
+
+// OriginOf returns the origin of err if it implements Originated, otherwise OriginLocal.
+func OriginOf(err Err) Origin {
+	if o, ok := err.(Originated); ok {
+		return o.GetOrigin()
+	}
+	return OriginLocal
+}
+
+// Clone returns a deep, mutable copy of err, see Serr.Clone. Errs not implementing Cloner are copied to a Serr
+// wrapping err with the same status, code, message, clones of its sub-errors and a copy of its Metadata.
+// It returns nil if err is nil.
+func Clone(err Err) Err {
+	if err == nil {
+		return nil
+	}
+	if c, ok := err.(Cloner); ok {
+		return c.Clone()
+	}
+	return &Serr{
+		error:      err,
+		HttpStatus: err.GetHttpStatus(),
+		Code:       err.GetCode(),
+		Message:    err.GetMessage(),
+		SubErrors:  cloneSubErrors(err.GetSubErrors(), map[*Serr]Err{}),
+		Metadata:   cloneValue(err.GetMetadata()),
+	}
+}
+
+// PublicMessage returns the client-facing message of err, see Serr.PublicMessage.
+// Errs not embedding a Serr never wrap the text of other errors in their message, so their message is returned.
+func PublicMessage(err Err) string {
+	if s := serrOf(err); s != nil {
+		return s.PublicMessage()
+	}
+	return err.GetMessage()
+}
+
+// IsFrozen reports whether err implements Freezer and is frozen.
+func IsFrozen(err Err) bool {
+	f, ok := err.(Freezer)
+	return ok && f.IsFrozen()
+}
+
+// ErrCode is a machine-readable error code.
+type ErrCode string
+
+// ErrCodeString converts a code received as a plain string, e.g. from an external API, to an ErrCode.
+func ErrCodeString(s string) ErrCode {
+	return ErrCode(s)
+}
+
+// Origin tells where an Err was created.
+type Origin int
+
+const (
+	// OriginLocal means the Err was created by this service.
+	OriginLocal Origin = iota
+	// OriginRemote means the Err was received from a downstream service.
+	OriginRemote
+)
+
 // Serr is the base error struct type.
 // Reference: https://github.com/microsoft/api-guidelines/blob/vNext/azure/Guidelines.md#handling-errors
 type Serr struct { //nolint:errname // lib
@@ -55,18 +157,35 @@ type Serr struct { //nolint:errname // lib
 
 	// HTTP status code
 	HttpStatus int `json:"-"`
-	// One of a server-defined set of error codes.
-	Code string `json:"code"                v:"required" dc:"Error code"`
+	// One of a server-defined set of error codes, prefixed with Namespace and a dot if namespaced.
+	Code ErrCode `json:"code"                v:"required" dc:"Error code"`
+	// Namespace of the code, e.g. "order" for "order.NotFound", empty if not namespaced.
+	Namespace string `json:"-"`
 	// A human-readable representation of the error.
 	Message string `json:"message"             v:"required" dc:"Error message"`
 	// An array of specific errors that led to this error.
 	SubErrors []Err `json:"subErrors,omitempty"              dc:"Sub-errors that led to this error"`
 	// Error metadata, useful for debugging, logging, generating i18n error messages etc.
 	Metadata any `json:"metadata,omitempty"               dc:"Error metadata"`
+	// Where the error was created.
+	Origin Origin `json:"-"`
+
+	// Whether the Err is read-only, see Freeze.
+	frozen bool
+	// Typed domain object carried by the Err, see WithPayload.
+	payload any
+	// Headers written with the Err, see ErrWithHTTPHeaders.
+	headers h.Header
+	// Severity set with WithSeverity, 0 to derive it from HttpStatus.
+	severity Severity
+	// Whether the Err holds the raw text of an error that is not an Err, see NewErrFromError.
+	internal bool
 }
 
-// ToErr converts any value to an *Err.
-// If x is not an *Err, the base will be ErrInternalServerError.
+// ToErr converts any value to an Err.
+// If x is or wraps an Err (of any implementation, e.g. an I18nErr), the outermost Err is returned as is.
+// Otherwise x is wrapped in a new Err with the base DefaultMapper maps it to, e.g. ErrTimeout for
+// context.DeadlineExceeded, or ErrInternalServerError if it maps to none.
 func ToErr(x any) Err {
 	if x == nil {
 		return nil
@@ -77,6 +196,12 @@ func ToErr(x any) Err {
 	case Err:
 		return v
 	case error:
+		if werr, ok := As[Err](v); ok {
+			return werr
+		}
+		if base := DefaultMapper.lookup(v); base != nil {
+			return NewErrFromError(base, v)
+		}
 		err = v
 	default:
 		err = fmt.Errorf("%v", v)
@@ -85,19 +210,79 @@ func ToErr(x any) Err {
 	return NewErrFromError(ErrInternalServerError, err)
 }
 
+// OnError, if set, is called with the code and HTTP status of every Err created by NewErr and NewErrFromError,
+// e.g. to count errors in metrics. It must be set before any Err is created and must be safe for concurrent use.
+var OnError func(code ErrCode, status int)
+
+// MostSpecific converts each non-nil error with ToErr and returns the most specific one:
+// client errors (4xx) are preferred over other statuses, then Errs with more sub-errors,
+// then Errs whose code is not the generic one for their status in HttpStatus2ErrMap.
+// Ties keep the earliest error. It returns nil if all errs are nil.
+func MostSpecific(errs ...error) Err {
+	var best Err
+	bestScore := -1
+	for _, err := range errs {
+		werr := ToErr(err)
+		if werr == nil {
+			continue
+		}
+		if score := specificity(werr); score > bestScore {
+			best, bestScore = werr, score
+		}
+	}
+	return best
+}
+
+func specificity(werr Err) int {
+	score := len(werr.GetSubErrors()) * 2
+	if status := werr.GetHttpStatus(); status >= 400 && status < 500 {
+		score += 1 << 16
+	}
+	if generic, ok := HttpStatus2ErrMap[werr.GetHttpStatus()]; !ok || generic.GetCode() != werr.GetCode() {
+		score++
+	}
+	return score
+}
+
 // NewBaseErr creates a new base Err.
-func NewBaseErr(httpStatus int, code, msg string) Err {
+func NewBaseErr(httpStatus int, code ErrCode, msg string) Err {
+	return &Serr{
+		error:      fmt.Errorf("%s %s", code, msg),
+		HttpStatus: httpStatus,
+		Code:       code,
+		Message:    msg,
+	}
+}
+
+// ErrSentinel creates a new frozen base Err to be declared as a package-level sentinel.
+// Its Set/Add methods panic, use Clone, NewErr or NewErrBuilder to derive a mutable Err instead.
+func ErrSentinel(httpStatus int, code ErrCode, msg string) Err {
 	return &Serr{
 		error:      fmt.Errorf("%s %s", code, msg),
 		HttpStatus: httpStatus,
 		Code:       code,
 		Message:    msg,
+		frozen:     true,
+	}
+}
+
+// NewRemoteErr creates a new Err received from a downstream service.
+// code is the code as received, a namespaced code like "order.NotFound" keeps its namespace.
+func NewRemoteErr(httpStatus int, code ErrCode, msg string) Err {
+	ns, _ := splitNamespace(code)
+	return &Serr{
+		error:      errors.New(string(code) + " " + msg),
+		HttpStatus: httpStatus,
+		Code:       code,
+		Namespace:  ns,
+		Message:    msg,
+		Origin:     OriginRemote,
 	}
 }
 
 // NewBaseErrFrom creates a new base Err from another base Err.
-func NewBaseErrFrom(base Err, code, msg string) Err {
-	if strings.TrimSpace(code) == "" {
+func NewBaseErrFrom(base Err, code ErrCode, msg string) Err {
+	if strings.TrimSpace(string(code)) == "" {
 		code = base.GetCode()
 	}
 	if strings.TrimSpace(msg) == "" {
@@ -122,44 +307,88 @@ func NewErr(base Err, msg, msgDetail string) Err {
 	if msgDetail != "" {
 		msg = msg + ": " + msgDetail
 	}
-	return &Serr{
+	return observe(&Serr{
 		error:      fmt.Errorf("%w: %s", base, msg),
 		HttpStatus: base.GetHttpStatus(),
-		Code:       base.GetCode(),
+		Code:       NamespacedCode(base),
+		Namespace:  namespaceOf(base),
 		Message:    msg,
-	}
+	})
 }
 
 // NewErrFromError creates a new Err from an error.
+// Its message is the base message, so the text of err is never exposed to clients through it.
+// Instead err is wrapped (see errors.Unwrap) and added as a sub-error: as is if it wraps an Err,
+// otherwise as an Err with the base code and err's text as message, which WriteError only writes
+// if verbose responses are enabled, see SetVerbose.
 func NewErrFromError(base Err, err error) Err {
-	msgDetail := err.Error()
+	var detail Err
 	werr := &Serr{}
 	if errors.As(err, &werr) {
-		if werr.Code == base.GetCode() && werr.Message == base.GetMessage() {
-			return werr
+		if werr.Code == NamespacedCode(base) && werr.Message == base.GetMessage() {
+			return observe(werr)
+		}
+		detail = werr
+	} else {
+		detail = &Serr{
+			error:      fmt.Errorf("%s %s", base.GetCode(), err.Error()),
+			HttpStatus: base.GetHttpStatus(),
+			Code:       base.GetCode(),
+			Message:    err.Error(),
+			internal:   true,
 		}
-		msgDetail = werr.Message
 	}
-	return &Serr{
+	return observe(&Serr{
 		error:      err,
 		HttpStatus: base.GetHttpStatus(),
-		Code:       base.GetCode(),
-		Message:    base.GetMessage() + ": " + msgDetail,
+		Code:       NamespacedCode(base),
+		Namespace:  namespaceOf(base),
+		Message:    base.GetMessage(),
+		SubErrors:  []Err{detail},
+	})
+}
+
+// observe calls OnError for err if set.
+func observe(err Err) Err {
+	if OnError != nil {
+		OnError(err.GetCode(), err.GetHttpStatus())
 	}
+	return err
 }
 
+// Error returns the HTTP status and the text of the wrapped error,
+// followed by a summary of the sub-errors and Metadata if enabled with SetVerboseErrors.
 func (e *Serr) Error() string {
-	return fmt.Sprintf("%v: %s", e.HttpStatus, e.error.Error())
+	msg := fmt.Sprintf("%v: %s", e.HttpStatus, e.error.Error())
+	if verboseErrors.Load() {
+		msg += e.summary()
+	}
+	return msg
 }
 
+// Is matches target by code only, so that errors.Is(err, ErrNotFound) holds for any Err derived from ErrNotFound.
+// Namespaced codes match by their namespaced form, or by their bare form if only one side is namespaced,
+// so that errors.Is(err, ErrNotFound) still holds if err's code is "order.NotFound".
+// Use Equal to also compare the status, message and Metadata.
 func (e *Serr) Is(target error) bool {
-	if t, ok := target.(*Serr); ok {
-		return t.Code == e.Code
+	if te, ok := target.(serrEmbedder); ok {
+		t := te.serr()
+		return t.Code == e.Code || (t.Namespace == "" || e.Namespace == "") && t.GetCode() == e.GetCode()
 	}
 	return errors.Is(e.error, target)
 }
 
+// As assigns e itself to target if it is a **Serr or *Err, otherwise it finds the first error
+// in the errors wrapped by e that matches target, see errors.As.
 func (e *Serr) As(target any) bool {
+	switch t := target.(type) {
+	case **Serr:
+		*t = e
+		return true
+	case *Err:
+		*t = e
+		return true
+	}
 	return errors.As(e.error, target)
 }
 
@@ -167,19 +396,83 @@ func (e *Serr) GetHttpStatus() int {
 	return e.HttpStatus
 }
 
-func (e *Serr) GetCode() string {
+// WithHttpStatus returns a mutable copy of the Serr with the given HTTP status but the same code and message,
+// e.g. to respond to ErrNotFound with 410 Gone in one endpoint. The Serr itself, Code2ErrMap and
+// HttpStatus2ErrMap are not modified, and Errs created from the copy with NewErr keep its status.
+func (e *Serr) WithHttpStatus(status int) *Serr {
+	//nolint:errcheck // type must match
+	c := e.Clone().(*Serr)
+	c.HttpStatus = status
+	return c
+}
+
+func (e *Serr) GetCode() ErrCode {
+	if e.Namespace == "" {
+		return e.Code
+	}
+	return ErrCode(strings.TrimPrefix(string(e.Code), e.Namespace+"."))
+}
+
+func (e *Serr) GetNamespacedCode() ErrCode {
 	return e.Code
 }
 
-func (e *Serr) SetCode(code string) {
-	e.Code = code
+// SetCode sets the code, keeping the namespace.
+func (e *Serr) SetCode(code ErrCode) {
+	if !e.mutable("SetCode") {
+		return
+	}
+	e.Code = namespacedCode(e.Namespace, code)
+}
+
+// namespaceOf returns the namespace of err's code, empty if not namespaced.
+func namespaceOf(err Err) string {
+	full, bare := string(NamespacedCode(err)), string(err.GetCode())
+	if full == bare {
+		return ""
+	}
+	return strings.TrimSuffix(full, "."+bare)
+}
+
+// splitNamespace splits a namespaced code like "order.NotFound" into its namespace and bare code.
+// The namespace is empty if code is not namespaced.
+func splitNamespace(code ErrCode) (string, ErrCode) {
+	i := strings.LastIndexByte(string(code), '.')
+	if i < 0 {
+		return "", code
+	}
+	return string(code[:i]), code[i+1:]
+}
+
+// namespacedCode returns code prefixed with ns and a dot, or code if ns is empty.
+func namespacedCode(ns string, code ErrCode) ErrCode {
+	if ns == "" {
+		return code
+	}
+	return ErrCode(ns + "." + string(code))
 }
 
 func (e *Serr) GetMessage() string {
 	return e.Message
 }
 
+// PublicMessage returns the client-facing message, which never includes the text of wrapped errors:
+// the message of an Err from NewErrFromError is the base message, and the sub-errors holding the raw text
+// of errors return the message of the base Err for their status instead.
+func (e *Serr) PublicMessage() string {
+	if !e.internal {
+		return e.Message
+	}
+	if base := StatusToErr(e.HttpStatus); base != nil {
+		return base.GetMessage()
+	}
+	return ""
+}
+
 func (e *Serr) SetMessage(msg string) {
+	if !e.mutable("SetMessage") {
+		return
+	}
 	e.Message = msg
 }
 
@@ -188,11 +481,17 @@ func (e *Serr) GetSubErrors() []Err {
 }
 
 func (e *Serr) SetSubErrors(errs []Err) {
+	if !e.mutable("SetSubErrors") {
+		return
+	}
 	e.SubErrors = errs
 }
 
 // AddSubErrors will append errs to the current sub-errors slice.
 func (e *Serr) AddSubErrors(errs ...Err) {
+	if !e.mutable("AddSubErrors") {
+		return
+	}
 	e.SubErrors = append(e.SubErrors, errs...)
 }
 
@@ -201,14 +500,416 @@ func (e *Serr) GetMetadata() any {
 }
 
 func (e *Serr) SetMetadata(meta any) {
+	if !e.mutable("SetMetadata") {
+		return
+	}
 	e.Metadata = meta
 }
 
-// IsErrOf checks if err wraps *Err and has the given code.
-func IsErrOf(err error, code string) bool {
-	var e *Serr
-	ok := errors.As(err, &e)
-	return ok && e.GetCode() == code
+func (e *Serr) GetOrigin() Origin {
+	return e.Origin
+}
+
+// Clone returns a deep, mutable copy of the Err.
+// Sub-errors are cloned, Metadata maps and slices ([]any, map[string]any) are copied recursively,
+// and the wrapped error is preserved, so errors.Is/As behave the same on the copy.
+// An Err that is its own (transitive) sub-error is copied to an Err that is its copy's sub-error.
+// Handlers should clone a shared base Err before mutating it.
+func (e *Serr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+// serrCloner is implemented by *Serr and the Err types embedding it, to clone them with the copies already made
+// of the Serrs of the Errs being cloned, see cloneInto.
+type serrCloner interface {
+	clone(copies map[*Serr]Err) Err
+}
+
+func (e *Serr) clone(copies map[*Serr]Err) Err {
+	c := new(Serr)
+	e.cloneInto(c, c, copies)
+	return c
+}
+
+// cloneInto copies e into dst, the Serr of outer, and records outer in copies as the copy of e, so that sub-errors
+// referring back to e are copied to outer. If copies is nil the copy shares e's sub-errors and Metadata,
+// see shallowCloneSerr.
+func (e *Serr) cloneInto(dst *Serr, outer Err, copies map[*Serr]Err) {
+	*dst = *e
+	dst.frozen = false
+	dst.headers = e.headers.Clone()
+	if copies == nil {
+		dst.SubErrors = slices.Clip(e.SubErrors)
+		return
+	}
+	copies[e] = outer
+	dst.SubErrors = cloneSubErrors(e.SubErrors, copies)
+	dst.Metadata = cloneValue(e.Metadata)
+}
+
+// cloneSubErrors returns a slice of clones of errs, nil if errs is nil.
+func cloneSubErrors(errs []Err, copies map[*Serr]Err) []Err {
+	if errs == nil {
+		return nil
+	}
+	c := make([]Err, len(errs))
+	for i, sub := range errs {
+		c[i] = cloneErr(sub, copies)
+	}
+	return c
+}
+
+// cloneErr returns the copy of err already in copies, or a clone of err.
+func cloneErr(err Err, copies map[*Serr]Err) Err {
+	if c, ok := copies[serrOf(err)]; ok {
+		return c
+	}
+	if c, ok := err.(serrCloner); ok {
+		return c.clone(copies)
+	}
+	return Clone(err)
+}
+
+// serrEmbedder is implemented by *Serr and the Err types embedding it.
+type serrEmbedder interface {
+	serr() *Serr
+}
+
+func (e *Serr) serr() *Serr {
+	return e
+}
+
+// serrOf returns the Serr embedded by err, nil if it embeds none.
+func serrOf(err Err) *Serr {
+	if s, ok := err.(serrEmbedder); ok {
+		return s.serr()
+	}
+	return nil
+}
+
+// enter records err in ancestors before walking its sub-errors, keyed by its Serr like MarshalJSON does.
+// It returns false if err already is an ancestor, i.e. its own (transitive) sub-error.
+// Errs not embedding a Serr are not recorded. Call leave after walking the sub-errors.
+func enter(ancestors map[*Serr]bool, err Err) bool {
+	s := serrOf(err)
+	if s == nil {
+		return true
+	}
+	if ancestors[s] {
+		return false
+	}
+	ancestors[s] = true
+	return true
+}
+
+// leave removes err from ancestors, see enter.
+func leave(ancestors map[*Serr]bool, err Err) {
+	delete(ancestors, serrOf(err))
+}
+
+// cloneSerr returns a mutable copy of err and the Serr it embeds.
+// Errs that do not embed a Serr are wrapped in a new Serr with the same status, code and message.
+func cloneSerr(err Err) (Err, *Serr) {
+	c := Clone(err)
+	if s, ok := c.(serrEmbedder); ok {
+		return c, s.serr()
+	}
+	serr := &Serr{
+		error:      err,
+		HttpStatus: err.GetHttpStatus(),
+		Code:       err.GetCode(),
+		Message:    err.GetMessage(),
+	}
+	return serr, serr
+}
+
+// shallowCloneSerr is like cloneSerr, but the copy shares the sub-errors and Metadata of err,
+// for callers replacing them like Redact.
+func shallowCloneSerr(err Err) (Err, *Serr) {
+	if s, ok := err.(serrCloner); ok {
+		c := s.clone(nil)
+		if e, ok := c.(serrEmbedder); ok {
+			return c, e.serr()
+		}
+	}
+	serr := &Serr{
+		error:      err,
+		HttpStatus: err.GetHttpStatus(),
+		Code:       err.GetCode(),
+		Message:    err.GetMessage(),
+		SubErrors:  slices.Clip(err.GetSubErrors()),
+		Metadata:   err.GetMetadata(),
+	}
+	return serr, serr
+}
+
+// Collapse returns a single-layer copy of the Err for clients: it has the code, status, message and Metadata
+// of the Err, and the distinct (see Equal) leaf sub-errors of all Errs in its tree as sub-errors,
+// but wraps nothing, so all intermediate wrapping is dropped.
+func (e *Serr) Collapse() Err {
+	var leaves []Err
+	walkErrTree(e, func(err error) {
+		if werr, ok := err.(Err); ok {
+			ancestors := map[*Serr]bool{}
+			enter(ancestors, werr)
+			for _, sub := range werr.GetSubErrors() {
+				leaves = appendLeaves(leaves, sub, ancestors)
+			}
+		}
+	})
+
+	return &Serr{
+		error:      fmt.Errorf("%s %s", e.Code, e.Message),
+		HttpStatus: e.HttpStatus,
+		Code:       e.Code,
+		Namespace:  e.Namespace,
+		Message:    e.Message,
+		SubErrors:  leaves,
+		Metadata:   cloneValue(e.Metadata),
+		Origin:     e.Origin,
+	}
+}
+
+// appendLeaves appends clones of the sub-errors of err without sub-errors, or of err itself if it has none,
+// to leaves if they are not in leaves yet. ancestors are the Serrs of the Errs containing err as a (transitive)
+// sub-error, a sub-error that is its own ancestor is skipped.
+func appendLeaves(leaves []Err, err Err, ancestors map[*Serr]bool) []Err {
+	if err == nil {
+		return leaves
+	}
+	if subs := err.GetSubErrors(); len(subs) > 0 {
+		if !enter(ancestors, err) {
+			return leaves
+		}
+		defer leave(ancestors, err)
+		for _, sub := range subs {
+			leaves = appendLeaves(leaves, sub, ancestors)
+		}
+		return leaves
+	}
+	if slices.ContainsFunc(leaves, func(leaf Err) bool { return Equal(leaf, err) }) {
+		return leaves
+	}
+	return append(leaves, Clone(err))
+}
+
+// FlatDetails returns the leaf sub-errors, i.e. those without sub-errors, of the Err's sub-error tree
+// in depth-first order, e.g. to log them on a single line. Sub-errors that are their own ancestor are skipped.
+func (e *Serr) FlatDetails() []Err {
+	var leaves []Err
+	ancestors := map[*Serr]bool{e: true}
+	var walk func(errs []Err)
+	walk = func(errs []Err) {
+		for _, sub := range errs {
+			switch {
+			case sub == nil:
+			case len(sub.GetSubErrors()) > 0:
+				if enter(ancestors, sub) {
+					walk(sub.GetSubErrors())
+					leave(ancestors, sub)
+				}
+			default:
+				leaves = append(leaves, sub)
+			}
+		}
+	}
+	walk(e.SubErrors)
+	return leaves
+}
+
+// Depth returns the maximum nesting depth of the Err's sub-errors, 0 if it has none.
+// Sub-errors that are their own ancestor are not counted.
+func (e *Serr) Depth() int {
+	return subErrorsDepth(e.SubErrors, map[*Serr]bool{e: true})
+}
+
+func subErrorsDepth(errs []Err, ancestors map[*Serr]bool) int {
+	depth := 0
+	for _, sub := range errs {
+		if sub == nil || !enter(ancestors, sub) {
+			continue
+		}
+		depth = max(depth, 1+subErrorsDepth(sub.GetSubErrors(), ancestors))
+		leave(ancestors, sub)
+	}
+	return depth
+}
+
+// cloneValue deep-copies map[string]any and []any values, other values are returned as is.
+func cloneValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		if x == nil {
+			return x
+		}
+		m := make(map[string]any, len(x))
+		for k, val := range x {
+			m[k] = cloneValue(val)
+		}
+		return m
+	case []any:
+		if x == nil {
+			return x
+		}
+		s := make([]any, len(x))
+		for i, val := range x {
+			s[i] = cloneValue(val)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// Freeze makes the Err read-only, see ErrSentinel.
+func (e *Serr) Freeze() {
+	e.frozen = true
+}
+
+func (e *Serr) IsFrozen() bool {
+	return e.frozen
+}
+
+// mutable reports whether the Err can be mutated by method.
+// Mutating a frozen Err panics, or is ignored in builds with the werror_release tag.
+func (e *Serr) mutable(method string) bool {
+	if !e.frozen {
+		return true
+	}
+	if panicOnFrozenMutation {
+		panic(fmt.Sprintf("werror: %s called on frozen error %s, use Clone, NewErr or NewErrBuilder first",
+			method, e.Code))
+	}
+	return false
+}
+
+// IsRemote checks if the outermost Err wrapped by err was received from a downstream service.
+func IsRemote(err error) bool {
+	var werr Err
+	return errors.As(err, &werr) && OriginOf(werr) == OriginRemote
+}
+
+// Is reports whether any error in err's tree matches base, see errors.Is.
+// Errs match by code.
+func Is(err error, base Err) bool {
+	return errors.Is(err, base)
+}
+
+// Equal reports whether a and b have the same code, HTTP status, message and Metadata (compared deeply).
+// Unlike Is, which only compares codes, two Errs with the same code but different messages are not equal.
+func Equal(a, b Err) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.GetCode() == b.GetCode() &&
+		a.GetHttpStatus() == b.GetHttpStatus() &&
+		a.GetMessage() == b.GetMessage() &&
+		reflect.DeepEqual(a.GetMetadata(), b.GetMetadata())
+}
+
+// EqualIgnoringParams is like Equal, but ignores the ignoreKeys of map[string]any Metadata,
+// e.g. timestamps or IDs, or all Metadata if no keys are given.
+func EqualIgnoringParams(a, b Err, ignoreKeys ...string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.GetCode() != b.GetCode() || a.GetHttpStatus() != b.GetHttpStatus() || a.GetMessage() != b.GetMessage() {
+		return false
+	}
+	if len(ignoreKeys) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(withoutParams(a.GetMetadata(), ignoreKeys), withoutParams(b.GetMetadata(), ignoreKeys))
+}
+
+// ErrEqual reports whether a and b have the same code, HTTP status and message, ignoring their Metadata.
+// It is the same as EqualIgnoringParams without keys.
+func ErrEqual(a, b Err) bool {
+	return EqualIgnoringParams(a, b)
+}
+
+// ErrEquivalent reports whether a and b have the same code and HTTP status, ignoring their messages and Metadata,
+// e.g. to check the kind of an Err in tests.
+func ErrEquivalent(a, b Err) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.GetCode() == b.GetCode() && a.GetHttpStatus() == b.GetHttpStatus()
+}
+
+// withoutParams returns a copy of meta without keys if it is a map[string]any, otherwise meta.
+func withoutParams(meta any, keys []string) any {
+	params, ok := meta.(map[string]any)
+	if !ok {
+		return meta
+	}
+	params = maps.Clone(params)
+	for _, key := range keys {
+		delete(params, key)
+	}
+	return params
+}
+
+// As finds the first error in err's tree of type T, see errors.As.
+// It returns the zero value of T and false if there is none.
+func As[T Err](err error) (T, bool) {
+	var target T
+	if errors.As(err, &target) {
+		return target, true
+	}
+	var zero T
+	return zero, false
+}
+
+//go:generate go run ./internal/genpredicates
+
+// IsErrOf checks if err wraps an Err with the given code.
+// Every branch of a joined error (see errors.Join) is inspected.
+// Typed predicates like IsNotFound are generated for the base Err codes in predicates.go.
+func IsErrOf(err error, code ErrCode) bool {
+	return slices.Contains(Codes(err), code)
+}
+
+// Codes returns the distinct codes of all Errs found in err's tree, outermost first.
+// Both Unwrap() error and Unwrap() []error chains are traversed.
+func Codes(err error) []ErrCode {
+	var codes []ErrCode
+	walkErrTree(err, func(e error) {
+		if werr, ok := e.(Err); ok && !slices.Contains(codes, werr.GetCode()) {
+			codes = append(codes, werr.GetCode())
+		}
+	})
+	return codes
+}
+
+// walkErrTree calls fn for err and every error it wraps, depth-first.
+func walkErrTree(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+	fn(err)
+	inner, joined := unwrapOnce(err)
+	if inner != nil {
+		walkErrTree(inner, fn)
+	}
+	for _, e := range joined {
+		walkErrTree(e, fn)
+	}
+}
+
+// unwrapOnce returns the error wrapped by err, or the errors joined by err, see errors.Unwrap.
+// Unlike errors.Unwrap, the error wrapped by a Serr, or by a type embedding one, is returned too.
+func unwrapOnce(err error) (error, []error) {
+	switch x := err.(type) {
+	case serrEmbedder:
+		return x.serr().error, nil
+	case interface{ Unwrap() error }:
+		return x.Unwrap(), nil
+	case interface{ Unwrap() []error }:
+		return nil, x.Unwrap()
+	default:
+		return nil, nil
+	}
 }
 
 // References:
@@ -219,94 +920,215 @@ const (
 	StatusClientClosedRequest = 499
 )
 
+// Base Err codes.
+const (
+	CodePartialSuccess                 ErrCode = "PartialSuccess"
+	CodeBadRequest                     ErrCode = "BadRequest"
+	CodeBadArgument                    ErrCode = "BadArgument"
+	CodeInvalidInput                   ErrCode = "InvalidInput"
+	CodeInvalidOperation               ErrCode = "InvalidOperation"
+	CodePasswordTooWeak                ErrCode = "PasswordTooWeak"
+	CodePaginationOutOfRange           ErrCode = "PaginationOutOfRange"
+	CodeUnauthorized                   ErrCode = "Unauthorized"
+	CodeInvalidLoginCredential         ErrCode = "InvalidLoginCredential"
+	CodeAlreadyLoggedIn                ErrCode = "AlreadyLoggedIn"
+	CodeInvalidAuthenticationInfo      ErrCode = "InvalidAuthenticationInfo"
+	CodeForbidden                      ErrCode = "Forbidden"
+	CodeAuthenticationFailed           ErrCode = "AuthenticationFailed"
+	CodeInsufficientAccountPermissions ErrCode = "InsufficientAccountPermissions"
+	CodeNotFound                       ErrCode = "NotFound"
+	CodeEndpointNotFound               ErrCode = "EndpointNotFound"
+	CodeResourceNotFound               ErrCode = "ResourceNotFound"
+	CodeMethodNotAllowed               ErrCode = "MethodNotAllowed"
+	CodeNotSupported                   ErrCode = "NotSupported"
+	CodeTimeout                        ErrCode = "Timeout"
+	CodeRequestTimeout                 ErrCode = "RequestTimeout"
+	CodeDeadlineExceeded               ErrCode = "DeadlineExceeded"
+	CodeConflict                       ErrCode = "Conflict"
+	CodeResourceAlreadyExists          ErrCode = "ResourceAlreadyExists"
+	CodeAccountAlreadyExists           ErrCode = "AccountAlreadyExists"
+	CodeIdempotencyKeyConflict         ErrCode = "IdempotencyKeyConflict"
+	CodeGone                           ErrCode = "Gone"
+	CodePreconditionFailed             ErrCode = "PreconditionFailed"
+	CodePayloadTooLarge                ErrCode = "PayloadTooLarge"
+	CodeRequestEntityTooLarge          ErrCode = "RequestEntityTooLarge"
+	CodeUnprocessableEntity            ErrCode = "UnprocessableEntity"
+	CodeLocked                         ErrCode = "Locked"
+	CodeFailedDependency               ErrCode = "FailedDependency"
+	CodeTooManyRequests                ErrCode = "TooManyRequests"
+	CodeClientClosedRequest            ErrCode = "ClientClosedRequest"
+	CodeRequestCanceled                ErrCode = "RequestCanceled"
+	CodeInternalError                  ErrCode = "InternalError"
+	CodeInternalServerError            ErrCode = "InternalServerError"
+	CodeNotImplemented                 ErrCode = "NotImplemented"
+	CodeServiceUnavailable             ErrCode = "ServiceUnavailable"
+	CodeServerBusy                     ErrCode = "ServerBusy"
+)
+
 // Base Errs.
 var (
-	ErrBadRequest       = NewBaseErr(h.StatusBadRequest, "BadRequest", "Bad request")
-	ErrBadArgument      = NewBaseErr(h.StatusBadRequest, "BadArgument", "Bad argument")
-	ErrInvalidInput     = NewBaseErr(h.StatusBadRequest, "InvalidInput", "Some request inputs are not valid")
-	ErrInvalidOperation = NewBaseErr(
+	ErrPartialSuccess   = ErrSentinel(h.StatusMultiStatus, CodePartialSuccess, "Some operations failed")
+	ErrBadRequest       = ErrSentinel(h.StatusBadRequest, CodeBadRequest, "Bad request")
+	ErrBadArgument      = ErrSentinel(h.StatusBadRequest, CodeBadArgument, "Bad argument")
+	ErrInvalidInput     = ErrSentinel(h.StatusBadRequest, CodeInvalidInput, "Some request inputs are not valid")
+	ErrInvalidOperation = ErrSentinel(
 		h.StatusBadRequest,
-		"InvalidOperation",
+		CodeInvalidOperation,
 		"The attempted operation is invalid",
 	)
-	ErrPasswordTooWeak        = NewBaseErr(h.StatusBadRequest, "PasswordTooWeak", "The specified password is too weak")
-	ErrUnauthorized           = NewBaseErr(h.StatusUnauthorized, "Unauthorized", "Unauthorized")
-	ErrInvalidLoginCredential = NewBaseErr(
+	ErrPasswordTooWeak = ErrSentinel(
+		h.StatusBadRequest,
+		CodePasswordTooWeak,
+		"The specified password is too weak",
+	)
+	ErrPaginationOutOfRange = ErrSentinel(
+		h.StatusBadRequest,
+		CodePaginationOutOfRange,
+		"The requested page is out of range",
+	)
+	ErrUnauthorized           = ErrSentinel(h.StatusUnauthorized, CodeUnauthorized, "Unauthorized")
+	ErrInvalidLoginCredential = ErrSentinel(
 		h.StatusUnauthorized,
-		"InvalidLoginCredential",
+		CodeInvalidLoginCredential,
 		"The login credential is invalid",
 	)
-	ErrAlreadyLoggedIn = NewBaseErr(
+	ErrAlreadyLoggedIn = ErrSentinel(
 		h.StatusUnauthorized,
-		"AlreadyLoggedIn",
+		CodeAlreadyLoggedIn,
 		"User already logged in in another place",
 	)
-	ErrInvalidAuthenticationInfo = NewBaseErr(
+	ErrInvalidAuthenticationInfo = ErrSentinel(
 		h.StatusUnauthorized,
-		"InvalidAuthenticationInfo",
+		CodeInvalidAuthenticationInfo,
 		"The authentication information is invalid",
 	)
-	ErrForbidden            = NewBaseErr(h.StatusForbidden, "Forbidden", "Forbidden")
-	ErrAuthenticationFailed = NewBaseErr(
+	ErrForbidden            = ErrSentinel(h.StatusForbidden, CodeForbidden, "Forbidden")
+	ErrAuthenticationFailed = ErrSentinel(
 		h.StatusForbidden,
-		"AuthenticationFailed",
+		CodeAuthenticationFailed,
 		"Server failed to authenticate the request. Make sure the authentication information is correct",
 	)
-	ErrInsufficientAccountPermissions = NewBaseErr(
+	ErrInsufficientAccountPermissions = ErrSentinel(
 		h.StatusForbidden,
-		"InsufficientAccountPermissions",
+		CodeInsufficientAccountPermissions,
 		"The account being accessed does not have sufficient permissions to execute this operation",
 	)
-	ErrNotFound         = NewBaseErr(h.StatusNotFound, "NotFound", "Not found")
-	ErrEndpointNotFound = NewBaseErr(h.StatusNotFound, "EndpointNotFound",
+	ErrNotFound         = ErrSentinel(h.StatusNotFound, CodeNotFound, "Not found")
+	ErrEndpointNotFound = ErrSentinel(h.StatusNotFound, CodeEndpointNotFound,
 		"The requested endpoint does not exist")
-	ErrResourceNotFound = NewBaseErr(h.StatusNotFound, "ResourceNotFound",
+	ErrResourceNotFound = ErrSentinel(h.StatusNotFound, CodeResourceNotFound,
 		"The specified resource does not exist")
-	ErrMethodNotAllowed      = NewBaseErr(h.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
-	ErrTimeout               = NewBaseErr(h.StatusRequestTimeout, "Timeout", "Timeout")
-	ErrRequestTimeout        = NewBaseErr(h.StatusRequestTimeout, "RequestTimeout", "Request timeout")
-	ErrConflict              = NewBaseErr(h.StatusConflict, "Conflict", "Conflict")
-	ErrResourceAlreadyExists = NewBaseErr(
+	ErrMethodNotAllowed      = ErrSentinel(h.StatusMethodNotAllowed, CodeMethodNotAllowed, "Method not allowed")
+	ErrNotSupported          = ErrSentinel(h.StatusMethodNotAllowed, CodeNotSupported, "Operation not supported")
+	ErrTimeout               = ErrSentinel(h.StatusRequestTimeout, CodeTimeout, "Timeout")
+	ErrRequestTimeout        = ErrSentinel(h.StatusRequestTimeout, CodeRequestTimeout, "Request timeout")
+	ErrDeadline              = ErrSentinel(h.StatusRequestTimeout, CodeDeadlineExceeded, "Request deadline exceeded")
+	ErrConflict              = ErrSentinel(h.StatusConflict, CodeConflict, "Conflict")
+	ErrResourceAlreadyExists = ErrSentinel(
 		h.StatusConflict,
-		"ResourceAlreadyExists",
+		CodeResourceAlreadyExists,
 		"The specified resource already exists",
 	)
-	ErrAccountAlreadyExists = NewBaseErr(
+	ErrAccountAlreadyExists = ErrSentinel(
 		h.StatusConflict,
-		"AccountAlreadyExists",
+		CodeAccountAlreadyExists,
 		"The specified account already exists",
 	)
-	ErrPreconditionFailed = NewBaseErr(h.StatusPreconditionFailed, "PreconditionFailed", "Precondition failed")
-	ErrPayloadTooLarge    = NewBaseErr(
+	ErrIdempotencyConflict = ErrSentinel(
+		h.StatusConflict,
+		CodeIdempotencyKeyConflict,
+		"The idempotency key has already been used with a different request",
+	)
+	ErrGone               = ErrSentinel(h.StatusGone, CodeGone, "The requested resource is no longer available")
+	ErrPreconditionFailed = ErrSentinel(h.StatusPreconditionFailed, CodePreconditionFailed, "Precondition failed")
+	ErrPayloadTooLarge    = ErrSentinel(
 		h.StatusRequestEntityTooLarge,
-		"PayloadTooLarge",
+		CodePayloadTooLarge,
 		"Payload too large",
 	)
-	ErrRequestEntityTooLarge = NewBaseErr(
+	ErrRequestEntityTooLarge = ErrSentinel(
 		h.StatusRequestEntityTooLarge,
-		"RequestEntityTooLarge",
+		CodeRequestEntityTooLarge,
 		"Request entity too large",
 	)
-	ErrTooManyRequests     = NewBaseErr(h.StatusTooManyRequests, "TooManyRequests", "Too many requests")
-	ErrClientClosedRequest = NewBaseErr(StatusClientClosedRequest, "ClientClosedRequest", "Client closed request")
-	ErrInternalError       = NewBaseErr(
+	ErrUnprocessableEntity = ErrSentinel(
+		h.StatusUnprocessableEntity,
+		CodeUnprocessableEntity,
+		"The request is well-formed but could not be processed",
+	)
+	ErrLocked           = ErrSentinel(h.StatusLocked, CodeLocked, "The resource is locked")
+	ErrFailedDependency = ErrSentinel(
+		h.StatusFailedDependency,
+		CodeFailedDependency,
+		"The request failed because a request it depends on failed",
+	)
+	ErrTooManyRequests     = ErrSentinel(h.StatusTooManyRequests, CodeTooManyRequests, "Too many requests")
+	ErrClientClosedRequest = ErrSentinel(StatusClientClosedRequest, CodeClientClosedRequest, "Client closed request")
+	ErrCanceled            = ErrSentinel(StatusClientClosedRequest, CodeRequestCanceled, "The request was canceled")
+	ErrInternalError       = ErrSentinel(
 		h.StatusInternalServerError,
-		"InternalError",
+		CodeInternalError,
 		"The system encountered an internal error",
 	)
-	ErrInternalServerError = NewBaseErr(
+	ErrInternalServerError = ErrSentinel(
 		h.StatusInternalServerError,
-		"InternalServerError",
+		CodeInternalServerError,
 		"The server encountered an internal error, please retry the request",
 	)
-	ErrServiceUnavailable = NewBaseErr(h.StatusServiceUnavailable, "ServiceUnavailable", "Service unavailable")
-	ErrServerBusy         = NewBaseErr(
+	ErrNotImplemented     = ErrSentinel(h.StatusNotImplemented, CodeNotImplemented, "Not implemented")
+	ErrServiceUnavailable = ErrSentinel(h.StatusServiceUnavailable, CodeServiceUnavailable, "Service unavailable")
+	ErrServerBusy         = ErrSentinel(
 		h.StatusServiceUnavailable,
-		"ServerBusy",
+		CodeServerBusy,
 		"The server is currently unable to receive requests. Please retry your request",
 	)
 )
 
+// baseErrs lists all base Errs of this package, see SnapshotCatalog.
+var baseErrs = []Err{
+	ErrPartialSuccess,
+	ErrBadRequest,
+	ErrBadArgument,
+	ErrInvalidInput,
+	ErrInvalidOperation,
+	ErrPasswordTooWeak,
+	ErrPaginationOutOfRange,
+	ErrUnauthorized,
+	ErrInvalidLoginCredential,
+	ErrAlreadyLoggedIn,
+	ErrInvalidAuthenticationInfo,
+	ErrForbidden,
+	ErrAuthenticationFailed,
+	ErrInsufficientAccountPermissions,
+	ErrNotFound,
+	ErrEndpointNotFound,
+	ErrResourceNotFound,
+	ErrMethodNotAllowed,
+	ErrNotSupported,
+	ErrTimeout,
+	ErrRequestTimeout,
+	ErrDeadline,
+	ErrConflict,
+	ErrResourceAlreadyExists,
+	ErrAccountAlreadyExists,
+	ErrIdempotencyConflict,
+	ErrGone,
+	ErrPreconditionFailed,
+	ErrPayloadTooLarge,
+	ErrRequestEntityTooLarge,
+	ErrUnprocessableEntity,
+	ErrLocked,
+	ErrFailedDependency,
+	ErrTooManyRequests,
+	ErrClientClosedRequest,
+	ErrCanceled,
+	ErrInternalError,
+	ErrInternalServerError,
+	ErrNotImplemented,
+	ErrServiceUnavailable,
+	ErrServerBusy,
+}
+
 var HttpStatus2ErrMap = map[int]Err{
 	h.StatusBadRequest:            ErrBadRequest,
 	h.StatusUnauthorized:          ErrUnauthorized,
@@ -315,9 +1137,27 @@ var HttpStatus2ErrMap = map[int]Err{
 	h.StatusMethodNotAllowed:      ErrMethodNotAllowed,
 	h.StatusRequestTimeout:        ErrRequestTimeout,
 	h.StatusConflict:              ErrConflict,
+	h.StatusGone:                  ErrGone,
 	h.StatusPreconditionFailed:    ErrPreconditionFailed,
 	h.StatusRequestEntityTooLarge: ErrRequestEntityTooLarge,
-	h.StatusTooManyRequests:       ErrTooManyRequests,
+	h.StatusUnprocessableEntity:   ErrUnprocessableEntity,
+	h.StatusLocked:                ErrLocked,
+	h.StatusFailedDependency:      ErrFailedDependency,
+	h.StatusTooManyRequests:       ErrThrottle,
+	StatusClientClosedRequest:     ErrClientClosedRequest,
 	h.StatusInternalServerError:   ErrInternalServerError,
+	h.StatusNotImplemented:        ErrNotImplemented,
 	h.StatusServiceUnavailable:    ErrServiceUnavailable,
 }
+
+// Code2ErrMap maps namespaced codes to base Errs,
+// it contains the base Errs of this package and those added by RegisterBaseErr.
+// Use LookupByCode to read it concurrently with RegisterBaseErr.
+var Code2ErrMap = newCode2ErrMap(baseErrs)
+
+// NewIdempotencyConflictErr creates an Err from ErrIdempotencyConflict with the conflicting key recorded in Metadata.
+func NewIdempotencyConflictErr(key string) Err {
+	err := NewErr(ErrIdempotencyConflict, "", "")
+	err.SetMetadata(map[string]any{"idempotencyKey": key})
+	return err
+}
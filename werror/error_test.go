@@ -2,8 +2,12 @@ package werror
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 type mockStringer struct {
@@ -63,7 +67,7 @@ func TestToErr(t *testing.T) {
 		name          string
 		input         interface{}
 		wantNil       bool
-		wantCode      string
+		wantCode      ErrCode
 		wantStatus    int
 		checkInternal bool // check if it converted to InternalServerError
 	}{
@@ -113,6 +117,230 @@ func TestToErr(t *testing.T) {
 	}
 }
 
+// mockErr is a custom Err implementation.
+type mockErr struct {
+	Err
+}
+
+func TestToErr_PreservesErr(t *testing.T) {
+	ierr, err := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}).Render(nil)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	custom := mockErr{Err: NewErr(ErrConflict, "Custom conflict", "")}
+
+	tests := []struct {
+		name  string
+		input any
+		want  Err
+	}{
+		{"Si18nerr", ierr, ierr},
+		{"custom Err", custom, custom},
+		{"wrapped Si18nerr", fmt.Errorf("get user: %w", ierr), ierr},
+		{"wrapped custom Err", fmt.Errorf("update: %w", custom), custom},
+		{"joined custom Err", errors.Join(errors.New("plain"), custom), custom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToErr(tt.input); got != tt.want {
+				t.Errorf("ToErr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	withMeta := func(base Err, meta any) Err {
+		err := NewErr(base, "", "")
+		err.SetMetadata(meta)
+		return err
+	}
+
+	tests := []struct {
+		name string
+		a, b Err
+		want bool
+	}{
+		{"same sentinel", ErrNotFound, ErrNotFound, true},
+		{"equal copies", NewErr(ErrNotFound, "User not found", ""), NewErr(ErrNotFound, "User not found", ""), true},
+		{"same code, different message", NewErr(ErrNotFound, "User not found", ""), ErrNotFound, false},
+		{"same message, different code", NewBaseErr(404, "UserNotFound", "Not found"), ErrNotFound, false},
+		{"same code, different status", NewBaseErr(410, CodeNotFound, "Not found"), ErrNotFound, false},
+		{
+			"equal metadata",
+			withMeta(ErrConflict, map[string]any{"ids": []any{1, 2}}),
+			withMeta(ErrConflict, map[string]any{"ids": []any{1, 2}}),
+			true,
+		},
+		{
+			"different metadata",
+			withMeta(ErrConflict, map[string]any{"id": 1}),
+			withMeta(ErrConflict, map[string]any{"id": 2}),
+			false,
+		},
+		{"nil and non-nil", nil, ErrNotFound, false},
+		{"both nil", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// errors.Is still matches by code only
+	if !errors.Is(NewErr(ErrNotFound, "User not found", ""), ErrNotFound) {
+		t.Error("errors.Is() should match Errs with the same code but different messages")
+	}
+}
+
+func TestEqualIgnoringParams(t *testing.T) {
+	withParams := func(params map[string]any) Err {
+		err := NewErr(ErrConflict, "Order already exists", "")
+		err.SetMetadata(params)
+		return err
+	}
+	first := withParams(map[string]any{"orderId": "o-1", "timestamp": "2026-01-01T00:00:00Z"})
+	later := withParams(map[string]any{"orderId": "o-1", "timestamp": "2026-01-01T00:00:05Z"})
+	other := withParams(map[string]any{"orderId": "o-2", "timestamp": "2026-01-01T00:00:05Z"})
+
+	tests := []struct {
+		name       string
+		a, b       Err
+		ignoreKeys []string
+		want       bool
+	}{
+		{"different timestamp ignored", first, later, []string{"timestamp"}, true},
+		{"different timestamp not ignored", first, later, []string{"requestId"}, false},
+		{"different ID", first, other, []string{"timestamp"}, false},
+		{"all params ignored", first, other, nil, true},
+		{"different message", first, NewErr(ErrConflict, "", ""), nil, false},
+		{"nil", nil, first, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualIgnoringParams(tt.a, tt.b, tt.ignoreKeys...); got != tt.want {
+				t.Errorf("EqualIgnoringParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if first.GetMetadata().(map[string]any)["timestamp"] == nil {
+		t.Error("EqualIgnoringParams() should not modify the Metadata")
+	}
+}
+
+func TestErrEqual(t *testing.T) {
+	withParams := NewErr(ErrConflict, "Order already exists", "")
+	withParams.SetMetadata(map[string]any{"orderId": "o-1"})
+	same := NewErr(ErrConflict, "Order already exists", "")
+	otherMsg := NewErr(ErrConflict, "", "")
+	otherStatus := NewBaseErr(500, CodeConflict, "Conflict")
+
+	tests := []struct {
+		name           string
+		a, b           Err
+		wantEqual      bool
+		wantEquivalent bool
+	}{
+		{"different params", withParams, same, true, true},
+		{"different message", same, otherMsg, false, true},
+		{"different status", otherMsg, otherStatus, false, false},
+		{"different code", same, ErrNotFound, false, false},
+		{"both nil", nil, nil, true, true},
+		{"one nil", nil, same, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrEqual(tt.a, tt.b); got != tt.wantEqual {
+				t.Errorf("ErrEqual() = %v, want %v", got, tt.wantEqual)
+			}
+			if got := ErrEquivalent(tt.a, tt.b); got != tt.wantEquivalent {
+				t.Errorf("ErrEquivalent() = %v, want %v", got, tt.wantEquivalent)
+			}
+		})
+	}
+}
+
+func TestSerr_Collapse(t *testing.T) {
+	nameRequired := NewErr(ErrInvalidInput, "Name is required", "")
+	emailInvalid := NewErr(ErrInvalidInput, "Email is invalid", "")
+
+	inner := NewErr(ErrInvalidInput, "Invalid user", "")
+	inner.AddSubErrors(nameRequired, emailInvalid)
+	middle := NewErrFromError(ErrBadRequest, fmt.Errorf("validate user: %w", inner))
+	middle.AddSubErrors(nameRequired)
+	outer := NewErrFromError(ErrBadArgument, fmt.Errorf("create user: %w", middle))
+	outer.SetMetadata(map[string]any{"field": "user"})
+
+	serr, ok := outer.(*Serr)
+	if !ok {
+		t.Fatalf("NewErrFromError() returned %T, want *Serr", outer)
+	}
+	got := serr.Collapse()
+
+	if got.GetCode() != CodeBadArgument || got.GetHttpStatus() != http.StatusBadRequest {
+		t.Errorf("Collapse() = %v, want the outermost code and status", got)
+	}
+	if got.GetMessage() != outer.GetMessage() {
+		t.Errorf("GetMessage() = %q, want %q", got.GetMessage(), outer.GetMessage())
+	}
+	if !reflect.DeepEqual(got.GetMetadata(), outer.GetMetadata()) {
+		t.Errorf("GetMetadata() = %v, want %v", got.GetMetadata(), outer.GetMetadata())
+	}
+
+	subs := got.GetSubErrors()
+	if len(subs) != 2 || !Equal(subs[0], nameRequired) || !Equal(subs[1], emailInvalid) {
+		t.Errorf("GetSubErrors() = %v, want the deduplicated leaves [%v %v]", subs, nameRequired, emailInvalid)
+	}
+	if codes := Codes(got); !reflect.DeepEqual(codes, []ErrCode{CodeBadArgument}) {
+		t.Errorf("Codes() = %v, the intermediate wrapping should be dropped", codes)
+	}
+}
+
+func TestSerr_FlatDetails(t *testing.T) {
+	leaf := func(msg string) Err { return NewErr(ErrInvalidInput, msg, "") }
+	withSubs := func(msg string, subs ...Err) *Serr {
+		err := NewErr(ErrBadRequest, msg, "")
+		err.AddSubErrors(subs...)
+		return err.(*Serr)
+	}
+
+	tests := []struct {
+		name      string
+		err       *Serr
+		want      []string
+		wantDepth int
+	}{
+		{"no sub-errors", withSubs("root"), nil, 0},
+		{"flat", withSubs("root", leaf("a"), leaf("b")), []string{"a", "b"}, 1},
+		{
+			"nested",
+			withSubs("root",
+				leaf("a"),
+				withSubs("group", leaf("b"), withSubs("inner", leaf("c"), leaf("d"))),
+				leaf("e"),
+			),
+			[]string{"a", "b", "c", "d", "e"},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range tt.err.FlatDetails() {
+				got = append(got, d.GetMessage())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlatDetails() = %v, want %v", got, tt.want)
+			}
+			if depth := tt.err.Depth(); depth != tt.wantDepth {
+				t.Errorf("Depth() = %d, want %d", depth, tt.wantDepth)
+			}
+		})
+	}
+}
+
 func TestErr_Is(t *testing.T) {
 	base := ErrBadRequest
 	wrapped := NewErrFromError(base, errors.New("inner detail"))
@@ -156,30 +384,586 @@ func TestErr_As(t *testing.T) {
 	}
 }
 
+func TestSerr_As_Receiver(t *testing.T) {
+	// The wrapped error is not an Err, so As can only succeed by assigning the receiver
+	werr := NewErrFromError(ErrBadRequest, errors.New("read failed"))
+
+	var target *Serr
+	if !werr.As(&target) || target != werr {
+		t.Errorf("werr.As(&*Serr) = %v, want werr", target)
+	}
+	var ierr Err
+	if !werr.As(&ierr) || ierr != werr {
+		t.Errorf("werr.As(&Err) = %v, want werr", ierr)
+	}
+
+	target = nil
+	if !errors.As(fmt.Errorf("handler: %w", werr), &target) || target != werr {
+		t.Errorf("errors.As(wrapped, &*Serr) = %v, want werr", target)
+	}
+
+	throttle := NewThrottleErr(1, 2, 3)
+	if !errors.As(throttle, &target) || target != throttle.Serr {
+		t.Errorf("errors.As(throttle, &*Serr) = %v, want the embedded Serr", target)
+	}
+}
+
 func TestNewErrFromError_Message(t *testing.T) {
 	base := ErrInternalServerError
 	detail := errors.New("database connection failed")
 
 	err := NewErrFromError(base, detail)
 
-	// Current implementation LEAKS the detail into Message.
-	// We test what it DOES, or what it SHOULD DO?
-	// User asked to build test "based on this version".
-	// If I test for correctness (no leak), it fails.
-	// If I test for current behavior (leak), it passes but solidifies bad practice.
-
-	// I will check the properties are set, without asserting exact message content policy
-	// to avoid failing on policy disagreement, but print it.
-
-	t.Logf("Generated Message: %s", err.GetMessage())
-
+	if err.GetMessage() != base.GetMessage() || PublicMessage(err) != base.GetMessage() {
+		t.Errorf("GetMessage(), PublicMessage() = %q, %q, want the base message %q only",
+			err.GetMessage(), PublicMessage(err), base.GetMessage())
+	}
 	if err.GetCode() != base.GetCode() {
 		t.Errorf("Code mismatch: got %v, want %v", err.GetCode(), base.GetCode())
 	}
-
-	// Check Details/Unwrap
-	// The current implementation puts the original error in e.error
 	if !errors.Is(err, detail) {
 		t.Error("NewErrFromError should wrap the original error")
 	}
+
+	subs := err.GetSubErrors()
+	if len(subs) != 1 || subs[0].GetMessage() != detail.Error() || subs[0].GetCode() != base.GetCode() {
+		t.Errorf("GetSubErrors() = %v, want the raw detail as the only sub-error", subs)
+	}
+	if got := PublicMessage(subs[0]); got != base.GetMessage() {
+		t.Errorf("PublicMessage() of the raw detail = %q, want the base message %q", got, base.GetMessage())
+	}
+}
+
+func TestNewErrFromError_WrappedErr(t *testing.T) {
+	inner := NewErr(ErrNotFound, "User not found", "")
+	err := NewErrFromError(ErrBadRequest, fmt.Errorf("load user: %w", inner))
+
+	if err.GetMessage() != ErrBadRequest.GetMessage() {
+		t.Errorf("GetMessage() = %q, want %q", err.GetMessage(), ErrBadRequest.GetMessage())
+	}
+	if subs := err.GetSubErrors(); len(subs) != 1 || subs[0] != inner {
+		t.Errorf("GetSubErrors() = %v, want [%v]", subs, inner)
+	}
+}
+
+func TestIsErrOf_Joined(t *testing.T) {
+	joined := errors.Join(errors.New("std error"), ErrNotFound)
+
+	if !IsErrOf(joined, "NotFound") {
+		t.Error("IsErrOf(joined, NotFound) = false, want true")
+	}
+	if IsErrOf(joined, "BadRequest") {
+		t.Error("IsErrOf(joined, BadRequest) = true, want false")
+	}
+	if IsErrOf(errors.New("std error"), "NotFound") {
+		t.Error("IsErrOf(std error, NotFound) = true, want false")
+	}
+
+	// Nested inside a Serr and another wrapping layer
+	wrapped := fmt.Errorf("outer: %w", NewErrFromError(ErrBadRequest, joined))
+	if !IsErrOf(wrapped, "NotFound") || !IsErrOf(wrapped, "BadRequest") {
+		t.Errorf("IsErrOf(wrapped) should match both codes, got %v", Codes(wrapped))
+	}
+}
+
+// userErr is an Err type defined outside of this package's knowledge, embedding a Serr.
+type userErr struct { //nolint:errname // test
+	*Serr
+}
+
+func TestCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []ErrCode
+	}{
+		{
+			name: "Nil error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "Standard error",
+			err:  errors.New("std error"),
+			want: nil,
+		},
+		{
+			name: "Base error",
+			err:  ErrNotFound,
+			want: []ErrCode{"NotFound"},
+		},
+		{
+			name: "Derived error is deduplicated",
+			err:  NewErr(ErrNotFound, "User not found", ""),
+			want: []ErrCode{"NotFound"},
+		},
+		{
+			name: "Joined errors",
+			err:  errors.Join(errors.New("std error"), ErrNotFound, ErrConflict),
+			want: []ErrCode{"NotFound", "Conflict"},
+		},
+		{
+			name: "Derived base error",
+			err:  NewBaseErrFrom(ErrNotFound, "UserNotFound", ""),
+			want: []ErrCode{"UserNotFound", "NotFound"},
+		},
+		{
+			name: "User type embedding Serr",
+			//nolint:errcheck // type must match
+			err:  &userErr{Serr: NewBaseErrFrom(ErrNotFound, "UserNotFound", "").(*Serr)},
+			want: []ErrCode{"UserNotFound", "NotFound"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Codes(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Codes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrIdempotencyConflict(t *testing.T) {
+	if ErrIdempotencyConflict.GetHttpStatus() != http.StatusConflict {
+		t.Errorf("GetHttpStatus() = %v, want %v", ErrIdempotencyConflict.GetHttpStatus(), http.StatusConflict)
+	}
+	if ErrIdempotencyConflict.GetCode() != "IdempotencyKeyConflict" {
+		t.Errorf("GetCode() = %v, want IdempotencyKeyConflict", ErrIdempotencyConflict.GetCode())
+	}
+}
+
+func TestBaseErrs_Status(t *testing.T) {
+	tests := []struct {
+		err        Err
+		wantStatus int
+		wantCode   ErrCode
+		wantMapped bool // Whether err is the Err of its status in HttpStatus2ErrMap
+	}{
+		{ErrNotSupported, http.StatusMethodNotAllowed, CodeNotSupported, false},
+		{ErrGone, http.StatusGone, CodeGone, true},
+		{ErrUnprocessableEntity, http.StatusUnprocessableEntity, CodeUnprocessableEntity, true},
+		{ErrLocked, http.StatusLocked, CodeLocked, true},
+		{ErrFailedDependency, http.StatusFailedDependency, CodeFailedDependency, true},
+		{ErrNotImplemented, http.StatusNotImplemented, CodeNotImplemented, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.wantCode), func(t *testing.T) {
+			if got := tt.err.GetHttpStatus(); got != tt.wantStatus {
+				t.Errorf("GetHttpStatus() = %v, want %v", got, tt.wantStatus)
+			}
+			if got := tt.err.GetCode(); got != tt.wantCode {
+				t.Errorf("GetCode() = %v, want %v", got, tt.wantCode)
+			}
+			if got := HttpStatus2ErrMap[tt.wantStatus] == tt.err; got != tt.wantMapped {
+				t.Errorf("HttpStatus2ErrMap[%v] == err is %v, want %v", tt.wantStatus, got, tt.wantMapped)
+			}
+			if got, ok := LookupByCode(tt.wantCode); !ok || got != tt.err {
+				t.Errorf("LookupByCode(%v) = %v, %v, want the sentinel", tt.wantCode, got, ok)
+			}
+		})
+	}
+}
+
+func TestNewIdempotencyConflictErr(t *testing.T) {
+	err := NewIdempotencyConflictErr("key-123")
+
+	if !errors.Is(err, ErrIdempotencyConflict) {
+		t.Error("NewIdempotencyConflictErr() should be Is ErrIdempotencyConflict")
+	}
+	if err.GetHttpStatus() != http.StatusConflict {
+		t.Errorf("GetHttpStatus() = %v, want %v", err.GetHttpStatus(), http.StatusConflict)
+	}
+
+	want := map[string]any{"idempotencyKey": "key-123"}
+	if !reflect.DeepEqual(err.GetMetadata(), want) {
+		t.Errorf("GetMetadata() = %v, want %v", err.GetMetadata(), want)
+	}
+	if ErrIdempotencyConflict.GetMetadata() != nil {
+		t.Error("NewIdempotencyConflictErr() should not modify the base error")
+	}
+}
+
+func TestIs(t *testing.T) {
+	// 3 levels deep: fmt wrapper -> derived Err -> derived Err -> base Err
+	level1 := NewErr(ErrNotFound, "User not found", "")
+	level2 := NewErrFromError(ErrNotFound, level1)
+	level3 := fmt.Errorf("handler: %w", level2)
+
+	tests := []struct {
+		name string
+		err  error
+		base Err
+		want bool
+	}{
+		{name: "Base matches itself", err: ErrNotFound, base: ErrNotFound, want: true},
+		{name: "Level 1 matches base", err: level1, base: ErrNotFound, want: true},
+		{name: "Level 3 matches base", err: level3, base: ErrNotFound, want: true},
+		{name: "Level 3 does not match other base", err: level3, base: ErrConflict, want: false},
+		{name: "Nil error", err: nil, base: ErrNotFound, want: false},
+		{name: "Standard error", err: errors.New("std error"), base: ErrNotFound, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err, tt.base); got != tt.want {
+				t.Errorf("Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAs(t *testing.T) {
+	ierr := MustNewI18nErr(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}, nil)
+	// 3 levels deep
+	wrapped := fmt.Errorf("level 3: %w", fmt.Errorf("level 2: %w", fmt.Errorf("level 1: %w", ierr)))
+
+	got, ok := As[*Si18nerr](wrapped)
+	if !ok {
+		t.Fatal("As[*Si18nerr]() ok = false, want true")
+	}
+	if got.GetCode() != "UserNotFound" {
+		t.Errorf("As[*Si18nerr]() code = %v, want UserNotFound", got.GetCode())
+	}
+
+	serr, ok := As[*Serr](fmt.Errorf("level 2: %w", fmt.Errorf("level 1: %w", ErrConflict)))
+	if !ok || serr.GetCode() != "Conflict" {
+		t.Errorf("As[*Serr]() = %v, %v, want Conflict, true", serr, ok)
+	}
+
+	none, ok := As[*Si18nerr](fmt.Errorf("level 1: %w", ErrConflict))
+	if ok || none != nil {
+		t.Errorf("As[*Si18nerr]() = %v, %v, want nil, false", none, ok)
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	remote := NewRemoteErr(http.StatusNotFound, "OrderNotFound", "Order not found")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantOrigin Origin
+		wantRemote bool
+	}{
+		{name: "Base error", err: ErrNotFound, wantOrigin: OriginLocal, wantRemote: false},
+		{name: "Derived error", err: NewErr(ErrNotFound, "User not found", ""), wantOrigin: OriginLocal},
+		{name: "Built error", err: NewErrBuilder(ErrNotFound).Build(), wantOrigin: OriginLocal},
+		{name: "Remote error", err: remote, wantOrigin: OriginRemote, wantRemote: true},
+		{name: "Wrapped remote error", err: fmt.Errorf("call: %w", remote), wantOrigin: OriginRemote, wantRemote: true},
+		{name: "Local error wrapping remote error", err: NewErr(remote, "", ""), wantOrigin: OriginLocal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			werr, ok := As[Err](tt.err)
+			if !ok {
+				t.Fatal("As[Err]() ok = false, want true")
+			}
+			if OriginOf(werr) != tt.wantOrigin {
+				t.Errorf("GetOrigin() = %v, want %v", OriginOf(werr), tt.wantOrigin)
+			}
+			if IsRemote(tt.err) != tt.wantRemote {
+				t.Errorf("IsRemote() = %v, want %v", IsRemote(tt.err), tt.wantRemote)
+			}
+		})
+	}
+
+	if IsRemote(errors.New("std error")) {
+		t.Error("IsRemote(std error) = true, want false")
+	}
+}
+
+func TestMostSpecific(t *testing.T) {
+	withSubErrors := NewErrBuilder(ErrInvalidInput).WithDetails(ErrBadArgument, ErrBadArgument).Build()
+
+	tests := []struct {
+		name     string
+		errs     []error
+		wantCode ErrCode
+	}{
+		{
+			name:     "Specific 404 over generic 500",
+			errs:     []error{ErrInternalServerError, ErrNotFound},
+			wantCode: "NotFound",
+		},
+		{name: "Order does not matter", errs: []error{ErrNotFound, ErrInternalServerError}, wantCode: "NotFound"},
+		{
+			name:     "Standard error is generic 500",
+			errs:     []error{errors.New("std error"), ErrResourceNotFound},
+			wantCode: "ResourceNotFound",
+		},
+		{
+			name:     "Specific code over generic code",
+			errs:     []error{ErrNotFound, ErrResourceNotFound},
+			wantCode: "ResourceNotFound",
+		},
+		{name: "Richer sub-errors", errs: []error{ErrInvalidInput, withSubErrors}, wantCode: "InvalidInput"},
+		{name: "Nil errors are skipped", errs: []error{nil, ErrConflict, nil}, wantCode: "Conflict"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MostSpecific(tt.errs...)
+			if got == nil {
+				t.Fatal("MostSpecific() = nil, want non-nil")
+			}
+			if got.GetCode() != tt.wantCode {
+				t.Errorf("MostSpecific() code = %v, want %v", got.GetCode(), tt.wantCode)
+			}
+		})
+	}
+
+	if got := MostSpecific(withSubErrors, ErrInvalidInput); got != withSubErrors {
+		t.Errorf("MostSpecific() = %v, want the error with sub-errors", got)
+	}
+	if got := MostSpecific(nil, nil); got != nil {
+		t.Errorf("MostSpecific(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestSerr_Clone(t *testing.T) {
+	clone := Clone(ErrBadRequest)
+
+	clone.SetCode("CustomBadRequest")
+	clone.SetMessage("Custom message")
+	clone.AddSubErrors(ErrBadArgument)
+	clone.SetMetadata(map[string]any{"key": "value"})
+
+	if clone.GetCode() != "CustomBadRequest" || clone.GetMessage() != "Custom message" {
+		t.Errorf("clone = %v %v, want CustomBadRequest Custom message", clone.GetCode(), clone.GetMessage())
+	}
+	if clone.GetHttpStatus() != http.StatusBadRequest {
+		t.Errorf("clone GetHttpStatus() = %v, want %v", clone.GetHttpStatus(), http.StatusBadRequest)
+	}
+	if ErrBadRequest.GetCode() != CodeBadRequest || ErrBadRequest.GetMessage() != "Bad request" {
+		t.Errorf("ErrBadRequest changed to %v %v", ErrBadRequest.GetCode(), ErrBadRequest.GetMessage())
+	}
+	if len(ErrBadRequest.GetSubErrors()) != 0 || ErrBadRequest.GetMetadata() != nil {
+		t.Error("ErrBadRequest sub-errors or metadata changed")
+	}
+}
+
+func TestSerr_WithHttpStatus(t *testing.T) {
+	//nolint:errcheck // type must match
+	gone := ErrResourceNotFound.(*Serr).WithHttpStatus(http.StatusGone)
+
+	if gone.GetHttpStatus() != http.StatusGone {
+		t.Errorf("GetHttpStatus() = %v, want %v", gone.GetHttpStatus(), http.StatusGone)
+	}
+	if gone.GetCode() != CodeResourceNotFound || gone.GetMessage() != ErrResourceNotFound.GetMessage() {
+		t.Errorf("WithHttpStatus() = %v %q, want the code and message of ErrResourceNotFound",
+			gone.GetCode(), gone.GetMessage())
+	}
+	if IsFrozen(gone) {
+		t.Error("WithHttpStatus() should return a mutable copy")
+	}
+	if ErrResourceNotFound.GetHttpStatus() != http.StatusNotFound {
+		t.Errorf("ErrResourceNotFound status changed to %v", ErrResourceNotFound.GetHttpStatus())
+	}
+	if !errors.Is(gone, ErrResourceNotFound) {
+		t.Error("errors.Is(gone, ErrResourceNotFound) = false, want true")
+	}
+
+	// Reverse lookups are unaffected
+	if got := HttpStatus2ErrMap[http.StatusNotFound]; got != ErrNotFound {
+		t.Errorf("HttpStatus2ErrMap[404] = %v, want ErrNotFound", got)
+	}
+	if got := StatusToErr(http.StatusNotFound); got != ErrNotFound {
+		t.Errorf("StatusToErr(404) = %v, want ErrNotFound", got)
+	}
+
+	// The override survives wrapping
+	wrapped := NewErr(gone, "", "user 42")
+	if wrapped.GetHttpStatus() != http.StatusGone || wrapped.GetCode() != CodeResourceNotFound {
+		t.Errorf("NewErr(gone) = %v %v, want %v %v",
+			wrapped.GetHttpStatus(), wrapped.GetCode(), http.StatusGone, CodeResourceNotFound)
+	}
+}
+
+func TestSi18nerr_Clone(t *testing.T) {
+	ierr := MustNewI18nErr(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}, nil)
+
+	clone, ok := Clone(ierr).(I18nErr)
+	if !ok {
+		t.Fatalf("Clone() = %T, want I18nErr", Clone(ierr))
+	}
+	clone.SetMessage("Changed")
+
+	if clone.GetI18n() != ierr.GetI18n() {
+		t.Error("Clone() should keep the i18n message")
+	}
+	if ierr.GetMessage() != "User not found" {
+		t.Errorf("original message changed to %v", ierr.GetMessage())
+	}
+}
+
+func TestOnError(t *testing.T) {
+	type call struct {
+		code   ErrCode
+		status int
+	}
+	var calls []call
+	OnError = func(code ErrCode, status int) {
+		calls = append(calls, call{code, status})
+	}
+	t.Cleanup(func() { OnError = nil })
+
+	_ = NewErr(ErrNotFound, "User not found", "")
+	_ = NewErrFromError(ErrConflict, errors.New("duplicate key"))
+
+	want := []call{{CodeNotFound, http.StatusNotFound}, {CodeConflict, http.StatusConflict}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("OnError calls = %v, want %v", calls, want)
+	}
+
+	OnError = nil
+	_ = NewErr(ErrNotFound, "", "")
+	if len(calls) != 2 {
+		t.Errorf("OnError should not be called once unset, got %v calls", len(calls))
+	}
+}
+
+func TestSerr_CloneDeep(t *testing.T) {
+	inner := errors.New("root cause")
+	sub := NewErr(ErrBadArgument, "Name is required", "")
+	sub.SetMetadata(map[string]any{"field": "name"})
+	orig := NewErrFromError(ErrBadRequest, inner) // Has the inner detail as its first sub-error
+	orig.AddSubErrors(sub)
+	orig.SetMetadata(map[string]any{"userId": 7, "tags": []any{"a", map[string]any{"k": "v"}}})
+
+	clone := Clone(orig)
+	clone.GetSubErrors()[1].SetMessage("Changed")
+	clone.GetSubErrors()[1].GetMetadata().(map[string]any)["field"] = "changed"
+	clone.GetMetadata().(map[string]any)["userId"] = 8
+	clone.GetMetadata().(map[string]any)["tags"].([]any)[1].(map[string]any)["k"] = "changed"
+	clone.AddSubErrors(ErrConflict)
+
+	if sub.GetMessage() != "Name is required" || sub.GetMetadata().(map[string]any)["field"] != "name" {
+		t.Errorf("original sub-error changed to %v %v", sub.GetMessage(), sub.GetMetadata())
+	}
+	wantMeta := map[string]any{"userId": 7, "tags": []any{"a", map[string]any{"k": "v"}}}
+	if !reflect.DeepEqual(orig.GetMetadata(), wantMeta) {
+		t.Errorf("original metadata changed to %v", orig.GetMetadata())
+	}
+	if len(orig.GetSubErrors()) != 2 {
+		t.Errorf("original sub-errors changed to %v", orig.GetSubErrors())
+	}
+	if !errors.Is(clone, inner) || !errors.Is(clone, ErrBadRequest) {
+		t.Error("Clone() should preserve the wrapped error")
+	}
+}
+
+// plainErr implements Err only, none of the optional interfaces.
+type plainErr struct { //nolint:errname // test
+	code ErrCode
+	msg  string
+	subs []Err
+	meta any
+}
+
+var _ Err = (*plainErr)(nil)
+
+func (e *plainErr) Error() string            { return e.msg }
+func (e *plainErr) Is(error) bool            { return false }
+func (e *plainErr) As(any) bool              { return false }
+func (e *plainErr) GetHttpStatus() int       { return http.StatusTeapot }
+func (e *plainErr) GetCode() ErrCode         { return e.code }
+func (e *plainErr) SetCode(code ErrCode)     { e.code = code }
+func (e *plainErr) GetMessage() string       { return e.msg }
+func (e *plainErr) SetMessage(msg string)    { e.msg = msg }
+func (e *plainErr) GetSubErrors() []Err      { return e.subs }
+func (e *plainErr) SetSubErrors(errs []Err)  { e.subs = errs }
+func (e *plainErr) AddSubErrors(errs ...Err) { e.subs = append(e.subs, errs...) }
+func (e *plainErr) GetMetadata() any         { return e.meta }
+func (e *plainErr) SetMetadata(meta any)     { e.meta = meta }
+
+func TestOptionalInterfaces_Fallbacks(t *testing.T) {
+	plain := &plainErr{
+		code: "Teapot",
+		msg:  "I'm a teapot",
+		subs: []Err{ErrNotFound},
+		meta: map[string]any{"k": "v"},
+	}
+
+	if got := NamespacedCode(plain); got != "Teapot" {
+		t.Errorf("NamespacedCode() = %v, want Teapot", got)
+	}
+	if got := OriginOf(plain); got != OriginLocal {
+		t.Errorf("OriginOf() = %v, want %v", got, OriginLocal)
+	}
+	if IsFrozen(plain) {
+		t.Error("IsFrozen() = true, want false")
+	}
+
+	clone := Clone(plain)
+	if !Equal(clone, plain) || len(clone.GetSubErrors()) != 1 || clone.GetSubErrors()[0] == ErrNotFound {
+		t.Errorf("Clone() = %v, want an equal Err with cloned sub-errors", clone)
+	}
+	//nolint:errcheck // type must match
+	clone.GetMetadata().(map[string]any)["k"] = "changed"
+	//nolint:errcheck // type must match
+	if plain.meta.(map[string]any)["k"] != "v" {
+		t.Error("Clone() shares Metadata with the original")
+	}
+	if !errors.Is(clone, plain) {
+		t.Error("errors.Is(clone, plain) = false, want true")
+	}
+	if Clone(nil) != nil {
+		t.Error("Clone(nil) != nil")
+	}
+}
+
+func TestClone_SelfReferencing(t *testing.T) {
+	tests := []struct {
+		name string
+		err  func() Err
+	}{
+		{"Serr", func() Err { return NewErr(ErrBadRequest, "", "") }},
+		{"Embedding type", func() Err { return NewThrottleErr(30, 2, 3) }},
+		{"I18nErr", func() Err {
+			return MustNewI18nErr(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			mid := NewErr(ErrNotFound, "", "")
+			mid.AddSubErrors(err)
+			err.AddSubErrors(err, mid)
+
+			clone := Clone(err)
+			subs := clone.GetSubErrors()
+			if len(subs) != 2 || subs[0] != clone || subs[1].GetSubErrors()[0] != clone {
+				t.Fatalf("Clone() sub-errors = %v, want the clone itself, directly and through a copy of mid", subs)
+			}
+			if subs[1] == mid {
+				t.Error("Clone() shares a sub-error with the original")
+			}
+		})
+	}
+}
+
+// newCyclicErr returns an Err that is its own sub-error, directly and through another Err.
+func newCyclicErr() Err {
+	err := NewErr(ErrBadRequest, "", "")
+	mid := NewErr(ErrNotFound, "", "")
+	mid.AddSubErrors(err, ErrConflict)
+	err.AddSubErrors(err, mid)
+	return err
+}
+
+func TestSerr_SelfReferencing(t *testing.T) {
+	//nolint:errcheck // type must match
+	err := newCyclicErr().(*Serr)
+
+	if got := err.FlatDetails(); len(got) != 1 || got[0] != ErrConflict {
+		t.Errorf("FlatDetails() = %v, want [%v]", got, ErrConflict)
+	}
+	if got := err.Depth(); got != 2 {
+		t.Errorf("Depth() = %d, want 2", got)
+	}
+	if got := err.Collapse().GetSubErrors(); len(got) != 1 || !Equal(got[0], ErrConflict) {
+		t.Errorf("Collapse() sub-errors = %v, want [%v]", got, ErrConflict)
+	}
 }
@@ -0,0 +1,117 @@
+package werror
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var verboseErrors atomic.Bool
+
+// SetVerboseErrors enables or disables a compact summary of the sub-errors and Metadata in Serr.Error,
+// e.g. "400: BadRequest Bad request [details: 2 (InvalidInput, NotFound), params: {userId:7}]",
+// so logs keep the context of an Err. It is disabled by default to not break existing log parsers.
+func SetVerboseErrors(enabled bool) {
+	verboseErrors.Store(enabled)
+}
+
+// summary returns the compact summary of e's sub-errors and Metadata appended to Error if verbose errors are
+// enabled, empty if there is nothing to summarize. Sub-errors are summarized by count and codes, not recursively.
+func (e *Serr) summary() string {
+	var parts []string
+	if len(e.SubErrors) > 0 {
+		codes := make([]string, len(e.SubErrors))
+		for i, sub := range e.SubErrors {
+			if sub != nil {
+				codes[i] = string(NamespacedCode(sub))
+			}
+		}
+		parts = append(parts,
+			"details: "+strconv.Itoa(len(e.SubErrors))+" ("+strings.Join(codes, ", ")+")")
+	}
+	if params := formatMetadata(e.Metadata); params != "" {
+		parts = append(parts, "params: "+params)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// formatMetadata returns meta compactly formatted, maps as "{k1:v1, k2:v2}" with sorted keys, empty if meta is
+// nil or an empty map.
+func formatMetadata(meta any) string {
+	switch meta := meta.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		if len(meta) == 0 {
+			return ""
+		}
+		params := make([]string, 0, len(meta))
+		for _, k := range slices.Sorted(maps.Keys(meta)) {
+			params = append(params, fmt.Sprintf("%s:%v", k, meta[k]))
+		}
+		return "{" + strings.Join(params, ", ") + "}"
+	default:
+		return fmt.Sprintf("%v", meta)
+	}
+}
+
+// Format implements fmt.Formatter: %s and %v print Error, %q the quoted Error, so wrapping with fmt.Errorf's %w
+// keeps the text of Error, and %+v prints the whole tree with codes, params and sub-errors, one Err per line, e.g.
+//
+//	400 BadRequest: Bad request
+//	  params: {userId:7}
+//	  400 InvalidInput: Name is required
+//
+// Types embedding *Serr and overriding Error must override Format too, see formatErr.
+func (e *Serr) Format(f fmt.State, verb rune) {
+	formatErr(f, verb, e)
+}
+
+// formatErr formats err for Format, using err's own Error.
+func formatErr(f fmt.State, verb rune, err Err) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			var b strings.Builder
+			writeErrTree(&b, err, 0, map[*Serr]bool{})
+			_, _ = io.WriteString(f, b.String())
+			return
+		}
+		_, _ = io.WriteString(f, err.Error())
+	case 's':
+		_, _ = io.WriteString(f, err.Error())
+	case 'q':
+		_, _ = io.WriteString(f, strconv.Quote(err.Error()))
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(werror.Err=%s)", verb, err.Error())
+	}
+}
+
+// writeErrTree writes err as "STATUS CODE: MESSAGE" followed by its params and, indented, its sub-errors.
+// ancestors are the Serrs of the Errs containing err as a (transitive) sub-error, a sub-error that is its own
+// ancestor is written as CodeCyclicReference.
+func writeErrTree(b *strings.Builder, err Err, depth int, ancestors map[*Serr]bool) {
+	indent := strings.Repeat("  ", depth)
+	if !enter(ancestors, err) {
+		b.WriteString(indent + string(CodeCyclicReference))
+		return
+	}
+	defer leave(ancestors, err)
+	fmt.Fprintf(b, "%s%d %s: %s", indent, err.GetHttpStatus(), NamespacedCode(err), err.GetMessage())
+	if params := formatMetadata(err.GetMetadata()); params != "" {
+		fmt.Fprintf(b, "\n%s  params: %s", indent, params)
+	}
+	for _, sub := range err.GetSubErrors() {
+		if sub != nil {
+			b.WriteString("\n")
+			writeErrTree(b, sub, depth+1, ancestors)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package werror
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestSetVerboseErrors(t *testing.T) {
+	withDetails := NewErrBuilder(ErrBadRequest).
+		WithDetails(ErrInvalidInput, ErrNotFound).
+		WithParam("userId", 7).
+		WithParam("name", "alice").
+		Build()
+	withMeta := NewErr(ErrBadRequest, "", "")
+	withMeta.SetMetadata([]string{"a", "b"})
+
+	tests := []struct {
+		name       string
+		err        Err
+		wantSuffix string
+	}{
+		{"No details", ErrBadRequest, ""},
+		{"Details and params", withDetails, " [details: 2 (InvalidInput, NotFound), params: {name:alice, userId:7}]"},
+		{"Non-map Metadata", withMeta, " [params: [a b]]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := tt.err.Error()
+
+			SetVerboseErrors(true)
+			t.Cleanup(func() { SetVerboseErrors(false) })
+			if got, want := tt.err.Error(), plain+tt.wantSuffix; got != want {
+				t.Errorf("Error() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSerr_Format(t *testing.T) {
+	derived := NewErr(ErrNotFound, "user 7", "")
+	throttle := NewThrottleErr(30, 2, 3)
+
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		want   string
+	}{
+		{"%s is Error", "%s", derived, derived.Error()},
+		{"%v is Error", "%v", derived, derived.Error()},
+		{"%q is quoted Error", "%q", derived, strconv.Quote(derived.Error())},
+		{"Wrapped with %w", "ctx: %w", derived, "ctx: " + derived.Error()},
+		{"Embedding type overriding Error", "%v", throttle, throttle.Error()},
+		{"Derived Error is unchanged", "%s", derived, "404: 404: NotFound Not found: user 7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Errorf(tt.format, tt.err).Error(); got != tt.want {
+				t.Errorf("Errorf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerr_Format_Tree(t *testing.T) {
+	err := NewErrBuilder(ErrBadRequest).
+		WithDetails(NewErr(ErrInvalidInput, "Name is required", ""), ErrNotFound).
+		WithParam("userId", 7).
+		Build()
+
+	want := "400 BadRequest: Bad request\n" +
+		"  params: {userId:7}\n" +
+		"  400 InvalidInput: Name is required\n" +
+		"  404 NotFound: Not found"
+	if got := fmt.Sprintf("%+v", err); got != want {
+		t.Errorf("Sprintf(%%+v) = %q, want %q", got, want)
+	}
+}
+
+func TestSerr_Format_SelfReferencing(t *testing.T) {
+	want := "400 BadRequest: Bad request\n" +
+		"  CyclicReference\n" +
+		"  404 NotFound: Not found\n" +
+		"    CyclicReference\n" +
+		"    409 Conflict: Conflict"
+	if got := fmt.Sprintf("%+v", newCyclicErr()); got != want {
+		t.Errorf("Sprintf(%%+v) = %q, want %q", got, want)
+	}
+}
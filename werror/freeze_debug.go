@@ -0,0 +1,6 @@
+//go:build !werror_release
+
+package werror
+
+// panicOnFrozenMutation makes mutating a frozen Err panic, to catch corrupting shared base Errs early.
+const panicOnFrozenMutation = true
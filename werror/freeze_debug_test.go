@@ -0,0 +1,82 @@
+//go:build !werror_release
+
+package werror
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestErrSentinel_PanicsOnMutation(t *testing.T) {
+	sentinel := ErrSentinel(http.StatusNotFound, "OrderNotFound", "Order not found")
+
+	tests := []struct {
+		name   string
+		mutate func(Err)
+	}{
+		{name: "SetCode", mutate: func(e Err) { e.SetCode("Other") }},
+		{name: "SetMessage", mutate: func(e Err) { e.SetMessage("Other") }},
+		{name: "SetSubErrors", mutate: func(e Err) { e.SetSubErrors([]Err{ErrBadArgument}) }},
+		{name: "AddSubErrors", mutate: func(e Err) { e.AddSubErrors(ErrBadArgument) }},
+		{name: "SetMetadata", mutate: func(e Err) { e.SetMetadata(map[string]any{"key": "value"}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s should panic on a sentinel error, but did not", tt.name)
+				}
+			}()
+
+			tt.mutate(sentinel)
+		})
+	}
+
+	if sentinel.GetMessage() != "Order not found" || sentinel.GetCode() != "OrderNotFound" {
+		t.Errorf("sentinel changed to %v %v", sentinel.GetCode(), sentinel.GetMessage())
+	}
+}
+
+func TestErrSentinel_PackageLevel(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("ErrBadRequest.SetMessage() should panic, but did not")
+		}
+	}()
+
+	ErrBadRequest.SetMessage("Mutated")
+}
+
+func TestSerr_Freeze(t *testing.T) {
+	base := NewBaseErr(http.StatusNotFound, "OrderNotFound", "Order not found")
+	if IsFrozen(base) {
+		t.Fatal("NewBaseErr() should not be frozen")
+	}
+	base.SetMessage("Order is missing")
+
+	//nolint:errcheck // type must match
+	base.(Freezer).Freeze()
+	if !IsFrozen(base) {
+		t.Fatal("IsFrozen() = false after Freeze()")
+	}
+	if !IsFrozen(ErrNotFound) {
+		t.Error("package-level base errors should be frozen")
+	}
+	if IsFrozen(Clone(ErrNotFound)) {
+		t.Error("Clone() should not be frozen")
+	}
+	if IsFrozen(NewErr(ErrNotFound, "", "")) {
+		t.Error("NewErr() should not be frozen")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("SetMessage() on a frozen error should panic in debug builds, but did not")
+		}
+		if base.GetMessage() != "Order is missing" {
+			t.Errorf("frozen error message changed to %v", base.GetMessage())
+		}
+	}()
+	base.SetMessage("Changed")
+}
@@ -0,0 +1,6 @@
+//go:build werror_release
+
+package werror
+
+// panicOnFrozenMutation is disabled in release builds, mutating a frozen Err is silently ignored.
+const panicOnFrozenMutation = false
@@ -0,0 +1,14 @@
+//go:build werror_release
+
+package werror
+
+import "testing"
+
+func TestSerr_FreezeRelease(t *testing.T) {
+	ErrBadRequest.SetMessage("Changed")
+	ErrBadRequest.AddSubErrors(ErrBadArgument)
+
+	if ErrBadRequest.GetMessage() != "Bad request" || len(ErrBadRequest.GetSubErrors()) != 0 {
+		t.Error("mutating a frozen error should be ignored in release builds")
+	}
+}
@@ -0,0 +1,42 @@
+// Package gin integrates werror with the Gin web framework.
+package gin
+
+import (
+	g "github.com/gin-gonic/gin"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// ErrorHandler returns a middleware that writes the last error added with c.Error as a werror JSON response.
+// Panics recovered in the chain are converted with werror.RecoverToErr,
+// so panic(werr) writes werr and other panics are written as werror.ErrInternalServerError.
+// Nothing is written if the response has already been written.
+func ErrorHandler() g.HandlerFunc {
+	return func(c *g.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				c.Abort()
+				if !c.Writer.Written() {
+					werror.WriteError(c.Writer, c.Request, werror.RecoverToErr(r))
+				}
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		werror.WriteError(c.Writer, c.Request, c.Errors.Last().Err)
+	}
+}
+
+// Abort records werr on c, writes it as a werror JSON response and aborts the handler chain.
+func Abort(c *g.Context, werr werror.Err) {
+	if werr == nil {
+		werr = werror.ErrInternalServerError
+	}
+	_ = c.Error(werr)
+	c.Abort()
+	werror.WriteError(c.Writer, c.Request, werr)
+}
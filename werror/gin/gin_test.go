@@ -0,0 +1,92 @@
+package gin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g "github.com/gin-gonic/gin"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func serve(t *testing.T, handler g.HandlerFunc) (int, map[string]any) {
+	t.Helper()
+
+	g.SetMode(g.TestMode)
+	r := g.New()
+	r.Use(ErrorHandler())
+	r.GET("/", handler)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    g.HandlerFunc
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "Err",
+			handler:    func(c *g.Context) { _ = c.Error(werror.ErrNotFound) },
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+		},
+		{
+			name: "Last error wins",
+			handler: func(c *g.Context) {
+				_ = c.Error(werror.ErrNotFound)
+				_ = c.Error(werror.ErrConflict)
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   "Conflict",
+		},
+		{
+			name:       "Standard error",
+			handler:    func(c *g.Context) { _ = c.Error(errors.New("database connection failed")) },
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+		{
+			name:       "Panic",
+			handler:    func(*g.Context) { panic("boom") },
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+		},
+		{
+			name:       "Panic with Err",
+			handler:    func(*g.Context) { panic(werror.ErrNotFound) },
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+		},
+		{
+			name:       "Abort",
+			handler:    func(c *g.Context) { Abort(c, werror.ErrForbidden) },
+			wantStatus: http.StatusForbidden,
+			wantCode:   "Forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serve(t, tt.handler)
+			if status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", status, tt.wantStatus)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("body code = %v, want %v", body["code"], tt.wantCode)
+			}
+		})
+	}
+}
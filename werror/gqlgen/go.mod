@@ -0,0 +1,17 @@
+module github.com/daotl/go-web-common/werror/gqlgen
+
+go 1.25
+
+require (
+	github.com/99designs/gqlgen v0.17.81
+	github.com/daotl/go-web-common v0.0.0-20261016035731-20c2196dff7d
+	github.com/vektah/gqlparser/v2 v2.5.30
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.6.0 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	golang.org/x/text v0.32.0 // indirect
+)
@@ -0,0 +1,72 @@
+// Package gqlgen integrates werror with the gqlgen GraphQL server.
+package gqlgen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// Presenter is a graphql.ErrorPresenterFunc that presents errors wrapping a werror.Err as GraphQL errors
+// with the Err's message, and its code, HTTP status, Metadata and sub-errors in the extensions:
+//
+//	{"code": "NotFound", "httpStatus": 404, "params": {...}, "details": [{"code": ..., "message": ...}]}
+//
+// Other errors are presented as werror.ErrInternalServerError to avoid leaking internals,
+// except GraphQL errors not wrapping any error, e.g. validation errors, which are returned as is.
+// The path and locations of a *gqlerror.Error are kept, otherwise the path is taken from ctx.
+func Presenter(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Err == nil {
+		return gqlErr
+	}
+
+	var werr werror.Err
+	if !errors.As(err, &werr) {
+		werr = werror.ErrInternalServerError
+	}
+
+	presented := &gqlerror.Error{
+		Err:        err,
+		Message:    werr.GetMessage(),
+		Extensions: extensions(werr, map[werror.Err]bool{}),
+	}
+	if gqlErr != nil {
+		presented.Path, presented.Locations = gqlErr.Path, gqlErr.Locations
+	} else {
+		presented.Path = graphql.GetPath(ctx)
+	}
+	return presented
+}
+
+// extensions returns the GraphQL error extensions describing werr.
+// ancestors are the Errs containing werr as a (transitive) sub-error, whose repetition is skipped.
+func extensions(werr werror.Err, ancestors map[werror.Err]bool) map[string]any {
+	ext := map[string]any{
+		"code":       string(werror.NamespacedCode(werr)),
+		"httpStatus": werr.GetHttpStatus(),
+	}
+	if meta := werr.GetMetadata(); meta != nil {
+		ext["params"] = meta
+	}
+	if subs := werr.GetSubErrors(); len(subs) > 0 {
+		ancestors[werr] = true
+		defer delete(ancestors, werr)
+
+		details := make([]map[string]any, 0, len(subs))
+		for _, sub := range subs {
+			if sub == nil || ancestors[sub] {
+				continue
+			}
+			detail := extensions(sub, ancestors)
+			detail["message"] = sub.GetMessage()
+			details = append(details, detail)
+		}
+		ext["details"] = details
+	}
+	return ext
+}
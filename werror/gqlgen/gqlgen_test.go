@@ -0,0 +1,90 @@
+package gqlgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func TestPresenter(t *testing.T) {
+	nameRequired := werror.NewErr(werror.ErrInvalidInput, "Name is required", "")
+	invalidUser := werror.NewErrBuilder(werror.ErrBadRequest).
+		WithMessage("Invalid user").
+		WithParam("userId", 7).
+		WithDetails(nameRequired).
+		Build()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantMsg string
+		wantExt map[string]any
+	}{
+		{
+			name:    "Err",
+			err:     werror.ErrNotFound,
+			wantMsg: "Not found",
+			wantExt: map[string]any{"code": "NotFound", "httpStatus": 404},
+		},
+		{
+			name:    "Err with params and details",
+			err:     fmt.Errorf("create user: %w", invalidUser),
+			wantMsg: "Invalid user",
+			wantExt: map[string]any{
+				"code":       "BadRequest",
+				"httpStatus": 400,
+				"params":     map[string]any{"userId": 7},
+				"details": []map[string]any{{
+					"code":       "InvalidInput",
+					"httpStatus": 400,
+					"message":    "Name is required",
+				}},
+			},
+		},
+		{
+			name:    "Unrecognized error",
+			err:     errors.New("pq: connection refused to db-1.internal"),
+			wantMsg: werror.ErrInternalServerError.GetMessage(),
+			wantExt: map[string]any{"code": "InternalServerError", "httpStatus": 500},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Presenter(context.Background(), tt.err)
+			if got.Message != tt.wantMsg {
+				t.Errorf("Message = %q, want %q", got.Message, tt.wantMsg)
+			}
+			if !reflect.DeepEqual(got.Extensions, tt.wantExt) {
+				t.Errorf("Extensions = %v, want %v", got.Extensions, tt.wantExt)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("the presented error should wrap the original error for logging")
+			}
+		})
+	}
+}
+
+func TestPresenter_GqlError(t *testing.T) {
+	path := ast.Path{ast.PathName("user")}
+
+	wrapped := gqlerror.WrapPath(path, werror.ErrForbidden)
+	got := Presenter(context.Background(), wrapped)
+	if got.Message != "Forbidden" || got.Extensions["code"] != "Forbidden" {
+		t.Errorf("Presenter() = %v %v, want the Err", got.Message, got.Extensions)
+	}
+	if !reflect.DeepEqual(got.Path, path) {
+		t.Errorf("Path = %v, want %v", got.Path, path)
+	}
+
+	validation := gqlerror.Errorf("Cannot query field \"foo\"")
+	if got := Presenter(context.Background(), validation); got != validation {
+		t.Errorf("Presenter() = %v, want the GraphQL error as is", got)
+	}
+}
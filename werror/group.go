@@ -0,0 +1,79 @@
+package werror
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrSentinelGroup is a named family of related base Errs, e.g. all authentication errors.
+type ErrSentinelGroup struct {
+	name    string
+	members []Err
+}
+
+var (
+	groupsMu sync.RWMutex
+	groups   []*ErrSentinelGroup
+)
+
+// Predefined groups of the base Errs of this package.
+var (
+	AuthGroup = NewGroup("authentication",
+		ErrUnauthorized,
+		ErrInvalidLoginCredential,
+		ErrAlreadyLoggedIn,
+		ErrInvalidAuthenticationInfo,
+		ErrForbidden,
+		ErrAuthenticationFailed,
+		ErrInsufficientAccountPermissions,
+	)
+	NotFoundGroup   = NewGroup("notFound", ErrNotFound, ErrEndpointNotFound, ErrResourceNotFound)
+	ValidationGroup = NewGroup("validation",
+		ErrBadArgument,
+		ErrInvalidInput,
+		ErrPasswordTooWeak,
+		ErrPaginationOutOfRange,
+	)
+)
+
+// NewGroup creates an ErrSentinelGroup and registers it for GroupOf.
+func NewGroup(name string, errs ...Err) *ErrSentinelGroup {
+	g := &ErrSentinelGroup{name: name, members: slices.Clone(errs)}
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+	groups = append(groups, g)
+	return g
+}
+
+// Name returns the name of the group.
+func (g *ErrSentinelGroup) Name() string {
+	return g.name
+}
+
+// Members returns the base Errs of the group.
+func (g *ErrSentinelGroup) Members() []Err {
+	return slices.Clone(g.members)
+}
+
+// Contains reports whether err matches any member of the group, see errors.Is.
+func (g *ErrSentinelGroup) Contains(err error) bool {
+	if err == nil {
+		return false
+	}
+	return slices.ContainsFunc(g.members, func(member Err) bool {
+		return errors.Is(err, member)
+	})
+}
+
+// GroupOf returns the first registered group containing err, see NewGroup.
+func GroupOf(err error) (*ErrSentinelGroup, bool) {
+	groupsMu.RLock()
+	defer groupsMu.RUnlock()
+	for _, g := range groups {
+		if g.Contains(err) {
+			return g, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,50 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrSentinelGroup_Contains(t *testing.T) {
+	tests := []struct {
+		name  string
+		group *ErrSentinelGroup
+		err   error
+		want  bool
+	}{
+		{"direct match", AuthGroup, ErrForbidden, true},
+		{"derived match", NotFoundGroup, NewErr(ErrResourceNotFound, "User not found", ""), true},
+		{"wrapped match", ValidationGroup, fmt.Errorf("validate: %w", NewErr(ErrInvalidInput, "", "")), true},
+		{"non-member", AuthGroup, ErrNotFound, false},
+		{"standard error", NotFoundGroup, errors.New("not found"), false},
+		{"nil", AuthGroup, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.group.Contains(tt.err); got != tt.want {
+				t.Errorf("%s.Contains() = %v, want %v", tt.group.Name(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupOf(t *testing.T) {
+	if g, ok := GroupOf(fmt.Errorf("login: %w", ErrInvalidLoginCredential)); !ok || g != AuthGroup {
+		t.Errorf("GroupOf() = %v, %v, want AuthGroup", g, ok)
+	}
+	if g, ok := GroupOf(ErrConflict); ok {
+		t.Errorf("GroupOf(ErrConflict) = %v, want none", g.Name())
+	}
+
+	errOrderMissing := ErrSentinel(404, "OrderMissing", "Order missing")
+	orders := NewGroup("orders", errOrderMissing)
+	t.Cleanup(func() {
+		groupsMu.Lock()
+		groups = groups[:len(groups)-1]
+		groupsMu.Unlock()
+	})
+	if g, ok := GroupOf(errOrderMissing); !ok || g != orders {
+		t.Errorf("GroupOf() = %v, %v, want the orders group", g, ok)
+	}
+}
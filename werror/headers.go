@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"maps"
+	h "net/http"
+	"slices"
+	"strings"
+)
+
+// HeaderedError is implemented by Errs carrying HTTP headers to be written with the error response,
+// e.g. WWW-Authenticate for 401, Allow for 405 or Location for 308. WriteError writes them.
+type HeaderedError interface {
+	Err
+	GetHTTPHeaders() h.Header
+}
+
+// GetHTTPHeaders returns the headers set with ErrWithHTTPHeaders, nil if none.
+func (e *Serr) GetHTTPHeaders() h.Header {
+	return e.headers
+}
+
+// ErrWithHTTPHeaders returns a copy of base carrying headers in addition to those it already has,
+// values of the same keys are replaced. The headers are written by WriteError and not serialized.
+// Errs that do not embed a Serr are wrapped in a new Err with the same status, code and message.
+func ErrWithHTTPHeaders(base Err, headers h.Header) Err {
+	c, serr := cloneSerr(base)
+	if serr.headers == nil {
+		serr.headers = make(h.Header, len(headers))
+	}
+	maps.Copy(serr.headers, headers.Clone())
+	return c
+}
+
+// NewUnauthorizedChallenge creates an Err from ErrUnauthorized with a WWW-Authenticate challenge,
+// e.g. `Bearer realm="api", error="invalid_token"`. Empty realm or errCode parameters are omitted.
+// Use WithChallenge to add more challenges.
+func NewUnauthorizedChallenge(scheme, realm, errCode string) Err {
+	return WithChallenge(NewErr(ErrUnauthorized, "", ""), scheme, realm, errCode)
+}
+
+// WithChallenge returns a copy of err with a WWW-Authenticate challenge added after its existing ones,
+// see NewUnauthorizedChallenge.
+func WithChallenge(err Err, scheme, realm, errCode string) Err {
+	var challenges []string
+	if herr, ok := err.(HeaderedError); ok {
+		challenges = herr.GetHTTPHeaders().Values("WWW-Authenticate")
+	}
+	challenges = append(slices.Clone(challenges), formatChallenge(scheme, realm, errCode))
+	return ErrWithHTTPHeaders(err, h.Header{"Www-Authenticate": challenges})
+}
+
+// formatChallenge formats a WWW-Authenticate challenge, see RFC 9110 section 11.6.1.
+func formatChallenge(scheme, realm, errCode string) string {
+	var params []string
+	if realm != "" {
+		params = append(params, "realm="+quoteAuthParam(realm))
+	}
+	if errCode != "" {
+		params = append(params, "error="+quoteAuthParam(errCode))
+	}
+	if len(params) == 0 {
+		return scheme
+	}
+	return scheme + " " + strings.Join(params, ", ")
+}
+
+// quoteAuthParam returns s as a quoted-string.
+func quoteAuthParam(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,117 @@
+package werror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrWithHTTPHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     Err
+		headers http.Header
+	}{
+		{
+			name:    "WWW-Authenticate",
+			err:     ErrUnauthorized,
+			headers: http.Header{"Www-Authenticate": {`Bearer realm="api"`}},
+		},
+		{
+			name:    "Allow with multiple values",
+			err:     ErrMethodNotAllowed,
+			headers: http.Header{"Allow": {"GET", "HEAD"}},
+		},
+		{
+			name:    "PaginationErr",
+			err:     NewPaginationErr(3, 2),
+			headers: http.Header{"Link": {`</items?page=2>; rel="last"`}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			werr := ErrWithHTTPHeaders(tt.err, tt.headers)
+			if werr.GetCode() != tt.err.GetCode() {
+				t.Errorf("GetCode() = %v, want %v", werr.GetCode(), tt.err.GetCode())
+			}
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), werr)
+
+			if rec.Code != tt.err.GetHttpStatus() {
+				t.Errorf("status = %d, want %d", rec.Code, tt.err.GetHttpStatus())
+			}
+			for key, want := range tt.headers {
+				if got := rec.Header().Values(key); !reflect.DeepEqual(got, want) {
+					t.Errorf("header %s = %v, want %v", key, got, want)
+				}
+			}
+			if strings.Contains(rec.Body.String(), "realm") || strings.Contains(rec.Body.String(), "HEAD") {
+				t.Errorf("body = %s, headers must not be serialized", rec.Body.String())
+			}
+		})
+	}
+
+	if ErrUnauthorized.(HeaderedError).GetHTTPHeaders() != nil {
+		t.Error("ErrWithHTTPHeaders() should not modify the base error")
+	}
+}
+
+func TestErrWithHTTPHeaders_Merge(t *testing.T) {
+	headers := http.Header{"Allow": {"GET"}}
+	werr := ErrWithHTTPHeaders(ErrMethodNotAllowed, headers)
+	werr = ErrWithHTTPHeaders(werr, http.Header{"Allow": {"POST"}, "Cache-Control": {"no-store"}})
+	headers.Set("Allow", "PUT")
+
+	want := http.Header{"Allow": {"POST"}, "Cache-Control": {"no-store"}}
+	if got := werr.(HeaderedError).GetHTTPHeaders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetHTTPHeaders() = %v, want %v", got, want)
+	}
+}
+
+func TestNewUnauthorizedChallenge(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Err
+		want []string
+	}{
+		{
+			name: "single challenge",
+			err:  NewUnauthorizedChallenge("Bearer", "api", "invalid_token"),
+			want: []string{`Bearer realm="api", error="invalid_token"`},
+		},
+		{
+			name: "multiple challenges",
+			err: WithChallenge(
+				NewUnauthorizedChallenge("Bearer", "api", ""),
+				"Basic", `say "hi"`, "",
+			),
+			want: []string{`Bearer realm="api"`, `Basic realm="say \"hi\""`},
+		},
+		{
+			name: "scheme only",
+			err:  NewUnauthorizedChallenge("Negotiate", "", ""),
+			want: []string{"Negotiate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, ErrUnauthorized) {
+				t.Errorf("errors.Is(err, ErrUnauthorized) = false, want true")
+			}
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if got := rec.Header().Values("WWW-Authenticate"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	h "net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// maxErrBodySize is the maximum number of bytes read from an error response body.
+const maxErrBodySize = 4 << 10
+
+var (
+	i18nBundle       atomic.Pointer[i18n.Bundle]
+	successCodes     atomic.Pointer[map[ErrCode]struct{}]
+	verboseResponses atomic.Bool
+)
+
+// SetVerbose enables or disables writing the internal sub-errors of Errs in WriteError, i.e. the raw text of
+// the errors converted by NewErrFromError and ToErr. When disabled they are removed, so internal details like
+// database queries never leak in production, while sub-errors and Metadata set by the caller, e.g. validation
+// fields or partial-success results, are still written. It is enabled by default in debug mode, see BuildMode.
+func SetVerbose(enabled bool) {
+	verboseResponses.Store(enabled)
+}
+
+// Verbose reports whether the internal sub-errors of written Errs are kept, see SetVerbose.
+func Verbose() bool {
+	return verboseResponses.Load()
+}
+
+func init() {
+	verboseResponses.Store(IsDebug())
+}
+
+// RegisterI18nBundle registers the bundle used by WriteError to localize I18nErr messages.
+// Passing nil disables localization.
+func RegisterI18nBundle(bundle *i18n.Bundle) {
+	i18nBundle.Store(bundle)
+}
+
+// SetSuccessCodes makes WriteError write errors with the given codes with status 200 instead of their own,
+// for legacy clients that treat them as data. The body is still the error. It replaces previously set codes.
+func SetSuccessCodes(codes ...ErrCode) {
+	set := make(map[ErrCode]struct{}, len(codes))
+	for _, code := range codes {
+		set[code] = struct{}{}
+	}
+	successCodes.Store(&set)
+}
+
+// WriteError writes err to w as a JSON response with the Err's HTTP status, see also SetSuccessCodes.
+// Errors not wrapping an Err are converted with ToErr, so those mapped by DefaultMapper get their status,
+// e.g. 404 for sql.ErrNoRows, and others are written as ErrInternalServerError. Their raw messages are internal
+// sub-errors, which only reach the client if verbose responses are enabled, see SetVerbose.
+// The headers of HeaderedErrors are written, the Retry-After header is set for ThrottleErrs,
+// and the X-RateLimit-* headers and, until the reset, Retry-After for RateLimitErrs.
+// I18nErr messages are localized according to r's Accept-Language header if a bundle is registered.
+// A nil err writes nothing.
+func WriteError(w h.ResponseWriter, r *h.Request, err error) {
+	if err == nil {
+		return
+	}
+	writeError(w, r, err)
+}
+
+// writeError writes the non-nil err to w, see WriteError.
+func writeError(w h.ResponseWriter, r *h.Request, err error) {
+	werr := ToErr(err)
+	if r != nil {
+		werr = localizeErr(werr, r.Header.Get("Accept-Language"))
+	}
+	if !verboseResponses.Load() && len(werr.GetSubErrors()) > 0 {
+		werr, _ = redactInternal(werr, map[*Serr]bool{})
+	}
+
+	status := werr.GetHttpStatus()
+	if status == 0 {
+		status = h.StatusInternalServerError
+	}
+	if set := successCodes.Load(); set != nil {
+		if _, ok := (*set)[werr.GetCode()]; ok {
+			status = h.StatusOK
+		}
+	}
+
+	body, merr := json.Marshal(werr)
+	if merr != nil {
+		status = h.StatusInternalServerError
+		body, _ = json.Marshal(ErrInternalServerError)
+	}
+
+	if herr, ok := werr.(HeaderedError); ok {
+		for key, values := range herr.GetHTTPHeaders() {
+			w.Header().Del(key)
+			for _, v := range values {
+				w.Header().Add(key, v)
+			}
+		}
+	}
+	if terr, ok := werr.(*ThrottleErr); ok && terr.RetryAfterSeconds > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(terr.RetryAfterSeconds))
+	}
+	if rerr, ok := werr.(*RateLimitErr); ok {
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rerr.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(rerr.Remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(rerr.ResetAt.Unix(), 10))
+		if wait := time.Until(rerr.ResetAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((wait+time.Second-1)/time.Second)))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// localizeErr returns a copy of werr with its message localized for acceptLanguage.
+// werr is returned unchanged if it is not an *Si18nerr, no bundle is registered or localization fails.
+func localizeErr(werr Err, acceptLanguage string) Err {
+	ierr, ok := werr.(*Si18nerr)
+	bundle := i18nBundle.Load()
+	if !ok || bundle == nil || ierr.GetI18n() == nil {
+		return werr
+	}
+
+	loc := i18n.NewLocalizer(bundle, acceptLanguage)
+	msg, tag, err := loc.LocalizeWithTag(&i18n.LocalizeConfig{
+		DefaultMessage: ierr.GetI18n(),
+		TemplateData:   ierr.GetRenderedData(),
+	})
+	if err != nil || msg == "" {
+		return werr
+	}
+
+	localized := *ierr
+	localized.Message = msg
+	localized.locale = tag.String()
+	return &localized
+}
+
+// StatusToErr returns the base Err for an HTTP status code from HttpStatus2ErrMap.
+// Statuses absent from the map fall back to the default of their class:
+// ErrBadRequest for 4xx and ErrInternalServerError for 5xx. It returns nil for other statuses.
+func StatusToErr(status int) Err {
+	if base, ok := HttpStatus2ErrMap[status]; ok {
+		return base
+	}
+	switch {
+	case status >= 400 && status < 500:
+		return ErrBadRequest
+	case status >= 500 && status < 600:
+		return ErrInternalServerError
+	default:
+		return nil
+	}
+}
+
+// ErrFromHTTPStatus returns the base Err for an HTTP status code, see StatusToErr.
+// Unlike StatusToErr, it never returns nil: statuses beyond 5xx fall back to ErrInternalServerError
+// and those below 4xx to ErrBadRequest.
+func ErrFromHTTPStatus(status int) Err {
+	if base := StatusToErr(status); base != nil {
+		return base
+	}
+	if status >= h.StatusInternalServerError {
+		return ErrInternalServerError
+	}
+	return ErrBadRequest
+}
+
+// ErrFromHTTPResponse creates a remote Err from a downstream service's error response.
+// If the body is a JSON {"code":"…","message":"…"} envelope, its code and message are used,
+// otherwise the code and message of ErrFromHTTPStatus.
+// At most 4 KB of the body is read, and the body is always closed.
+func ErrFromHTTPResponse(resp *h.Response) Err {
+	if resp == nil {
+		return ErrInternalServerError
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrBodySize))
+	if err == nil && json.Unmarshal(body, &envelope) == nil && envelope.Code != "" {
+		return NewRemoteErr(resp.StatusCode, ErrCodeString(envelope.Code), envelope.Message)
+	}
+
+	base := ErrFromHTTPStatus(resp.StatusCode)
+	return NewRemoteErr(resp.StatusCode, base.GetCode(), base.GetMessage())
+}
+
+// FromNetError creates an Err from a transport error returned when calling a downstream service.
+// Context errors map like ErrFromContext, other timeouts to ErrTimeout and other errors to ErrServiceUnavailable.
+func FromNetError(err error) Err {
+	if err == nil {
+		return nil
+	}
+
+	if werr := fromContextErr(err); werr != nil {
+		return werr
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return NewErrFromError(ErrTimeout, err)
+	}
+	return NewErrFromError(ErrServiceUnavailable, err)
+}
+
+// DoRequest sends req with client (http.DefaultClient if nil) and returns the response if its status is 2xx.
+// Transport errors are converted with FromNetError and non-2xx responses with ErrFromHTTPResponse,
+// in both cases the returned response is nil.
+func DoRequest(client *h.Client, req *h.Request) (*h.Response, Err) {
+	if client == nil {
+		client = h.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, FromNetError(err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, ErrFromHTTPResponse(resp)
+	}
+	return resp, nil
+}
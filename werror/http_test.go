@@ -0,0 +1,430 @@
+package werror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestWriteError(t *testing.T) {
+	saved := Verbose()
+	SetVerbose(false)
+	t.Cleanup(func() { SetVerbose(saved) })
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMsg    string
+	}{
+		{
+			name:       "Base error",
+			err:        ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NotFound",
+			wantMsg:    "Not found",
+		},
+		{
+			name:       "Derived error",
+			err:        NewErr(ErrBadRequest, "Name is required", ""),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BadRequest",
+			wantMsg:    "Name is required",
+		},
+		{
+			name:       "Wrapped Err",
+			err:        fmt.Errorf("handler: %w", ErrConflict),
+			wantStatus: http.StatusConflict,
+			wantCode:   "Conflict",
+			wantMsg:    "Conflict",
+		},
+		{
+			name:       "Error mapped by DefaultMapper",
+			err:        fmt.Errorf("get user: %w", sql.ErrNoRows),
+			wantStatus: http.StatusNotFound,
+			wantCode:   string(ErrResourceNotFound.GetCode()),
+			wantMsg:    ErrResourceNotFound.GetMessage(),
+		},
+		{
+			name:       "Unknown error does not leak",
+			err:        errors.New("database connection failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "InternalServerError",
+			wantMsg:    ErrInternalServerError.GetMessage(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %v, want application/json", ct)
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("json.Unmarshal() failed: %v", err)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("body code = %v, want %v", body["code"], tt.wantCode)
+			}
+			if body["message"] != tt.wantMsg {
+				t.Errorf("body message = %v, want %v", body["message"], tt.wantMsg)
+			}
+			if strings.Contains(rec.Body.String(), "database connection failed") {
+				t.Errorf("body = %s, should not leak the raw error unless verbose", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteError_Nil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("WriteError(nil) wrote body %q, want none", rec.Body.String())
+	}
+}
+
+func TestWriteError_Localized(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.Chinese, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "未找到用户 {{.Name}}",
+	})
+	RegisterI18nBundle(bundle)
+	t.Cleanup(func() { RegisterI18nBundle(nil) })
+
+	ierr := MustNewI18nErr(ErrNotFound, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "User {{.Name}} not found",
+	}, map[string]string{"Name": "Alice"})
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantMsg        string
+	}{
+		{
+			name:           "Translated locale",
+			acceptLanguage: "zh",
+			wantMsg:        "未找到用户 Alice",
+		},
+		{
+			name:           "Default locale",
+			acceptLanguage: "en",
+			wantMsg:        "User Alice not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			WriteError(rec, req, ierr)
+
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("status = %v, want %v", rec.Code, http.StatusNotFound)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantMsg) {
+				t.Errorf("body = %v, want message %q", rec.Body.String(), tt.wantMsg)
+			}
+		})
+	}
+
+	if ierr.GetMessage() != "User Alice not found" {
+		t.Errorf("WriteError() should not modify the error, got message %q", ierr.GetMessage())
+	}
+}
+
+func TestErrFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Err
+	}{
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusConflict, want: ErrConflict},
+		{status: http.StatusGone, want: ErrGone},
+		{status: http.StatusUnprocessableEntity, want: ErrUnprocessableEntity},
+		{status: http.StatusNotImplemented, want: ErrNotImplemented},
+		{status: http.StatusTeapot, want: ErrBadRequest},
+		{status: http.StatusBadGateway, want: ErrInternalServerError},
+		{status: http.StatusOK, want: ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			if got := ErrFromHTTPStatus(tt.status); got != tt.want {
+				t.Errorf("ErrFromHTTPStatus(%v) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusToErr(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Err
+	}{
+		{status: http.StatusForbidden, want: ErrForbidden},
+		{status: http.StatusRequestEntityTooLarge, want: ErrRequestEntityTooLarge},
+		{status: StatusClientClosedRequest, want: ErrClientClosedRequest},
+		{status: http.StatusGone, want: ErrGone},
+		{status: http.StatusUnprocessableEntity, want: ErrUnprocessableEntity},
+		{status: http.StatusNotImplemented, want: ErrNotImplemented},
+		{status: http.StatusTeapot, want: ErrBadRequest},
+		{status: http.StatusGatewayTimeout, want: ErrInternalServerError},
+		{status: http.StatusOK, want: nil},
+		{status: http.StatusFound, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			if got := StatusToErr(tt.status); got != tt.want {
+				t.Errorf("StatusToErr(%v) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrFromHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantCode    ErrCode
+		wantMsg     string
+	}{
+		{
+			name:        "JSON envelope",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"code":"OrderNotFound","message":"Order 42 not found"}`,
+			wantCode:    "OrderNotFound",
+			wantMsg:     "Order 42 not found",
+		},
+		{
+			name:        "Non-JSON body",
+			status:      http.StatusBadGateway,
+			contentType: "text/html",
+			body:        "<html>Bad gateway</html>",
+			wantCode:    "InternalServerError",
+			wantMsg:     ErrInternalServerError.GetMessage(),
+		},
+		{
+			name:        "JSON without code",
+			status:      http.StatusConflict,
+			contentType: "application/json",
+			body:        `{"error":"conflict"}`,
+			wantCode:    "Conflict",
+			wantMsg:     "Conflict",
+		},
+		{
+			name:        "Body larger than limit",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"code":"TooLong","message":"` + strings.Repeat("x", 8<<10) + `"}`,
+			wantCode:    "BadRequest",
+			wantMsg:     "Bad request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("http.Get() failed: %v", err)
+			}
+			werr := ErrFromHTTPResponse(resp)
+
+			if werr.GetHttpStatus() != tt.status {
+				t.Errorf("GetHttpStatus() = %v, want %v", werr.GetHttpStatus(), tt.status)
+			}
+			if werr.GetCode() != tt.wantCode {
+				t.Errorf("GetCode() = %v, want %v", werr.GetCode(), tt.wantCode)
+			}
+			if werr.GetMessage() != tt.wantMsg {
+				t.Errorf("GetMessage() = %v, want %v", werr.GetMessage(), tt.wantMsg)
+			}
+			if !IsRemote(werr) {
+				t.Error("IsRemote() = false, want true")
+			}
+		})
+	}
+}
+
+func TestFromNetError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Err
+	}{
+		{name: "Canceled", err: context.Canceled, want: ErrClientClosedRequest},
+		{name: "Deadline exceeded", err: fmt.Errorf("dial: %w", context.DeadlineExceeded), want: ErrTimeout},
+		{name: "Net timeout", err: &net.DNSError{Err: "timeout", IsTimeout: true}, want: ErrTimeout},
+		{name: "Other error", err: errors.New("connection refused"), want: ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromNetError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("FromNetError() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("FromNetError() should wrap the original error")
+			}
+		})
+	}
+
+	if FromNetError(nil) != nil {
+		t.Error("FromNetError(nil) should be nil")
+	}
+}
+
+func TestDoRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"OrderNotFound","message":"Order not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/", nil)
+		resp, werr := DoRequest(srv.Client(), req)
+		if werr != nil {
+			t.Fatalf("DoRequest() unexpected error = %v", werr)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/missing", nil)
+		resp, werr := DoRequest(srv.Client(), req)
+		if resp != nil {
+			t.Errorf("DoRequest() response = %v, want nil", resp)
+		}
+		if werr == nil || werr.GetCode() != "OrderNotFound" || werr.GetHttpStatus() != http.StatusNotFound {
+			t.Errorf("DoRequest() error = %v, want OrderNotFound 404", werr)
+		}
+	})
+
+	t.Run("Transport failure", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, closed.URL, nil)
+		resp, werr := DoRequest(closed.Client(), req)
+		if resp != nil {
+			t.Errorf("DoRequest() response = %v, want nil", resp)
+		}
+		if !errors.Is(werr, ErrServiceUnavailable) {
+			t.Errorf("DoRequest() error = %v, want %v", werr, ErrServiceUnavailable)
+		}
+	})
+}
+
+func TestSetSuccessCodes(t *testing.T) {
+	SetSuccessCodes(CodeResourceNotFound)
+	t.Cleanup(func() { SetSuccessCodes() })
+
+	tests := []struct {
+		name       string
+		err        Err
+		wantStatus int
+	}{
+		{name: "Success-listed code", err: ErrResourceNotFound, wantStatus: http.StatusOK},
+		{name: "Other code with same status", err: ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "Other code", err: ErrConflict, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), `"code":"`+string(tt.err.GetCode())+`"`) {
+				t.Errorf("body = %v, want the error", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetVerbose(t *testing.T) {
+	if Verbose() != IsDebug() {
+		t.Fatalf("Verbose() = %v, want verbose responses by default only in debug mode", Verbose())
+	}
+
+	tests := []struct {
+		name        string
+		verbose     bool
+		wantDetails bool
+	}{
+		{name: "Verbose", verbose: true, wantDetails: true},
+		{name: "Terse", verbose: false, wantDetails: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := Verbose()
+			SetVerbose(tt.verbose)
+			t.Cleanup(func() { SetVerbose(saved) })
+
+			err := NewErr(ErrConflict, "", "")
+			err.AddSubErrors(newSensitiveErr(), NewErr(ErrInvalidInput, "Name is required", ""))
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), err)
+
+			body := rec.Body.String()
+			if got := strings.Contains(body, "SELECT"); got != tt.wantDetails {
+				t.Errorf("body = %s, want internal details %v", body, tt.wantDetails)
+			}
+			for _, want := range []string{`"code":"Conflict"`, `"host":"db-1.internal"`, "Name is required"} {
+				if !strings.Contains(body, want) {
+					t.Errorf("body = %s, want it to contain %s", body, want)
+				}
+			}
+			if len(err.GetSubErrors()[0].GetSubErrors()) == 0 {
+				t.Error("WriteError() should not modify the Err")
+			}
+		})
+	}
+}
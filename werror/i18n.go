@@ -4,22 +4,52 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"log/slog"
+	h "net/http"
 	"strings"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	i18ntmpl "github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"golang.org/x/text/language"
 )
 
 var (
 	ErrI18nMessageOtherMissing = errors.New("i18n.Message.Other is missing")
 	ErrI18nTemplateMissing     = errors.New("i18nTmpl is missing")
+	ErrI18nTranslationMissing  = errors.New("i18n translation is missing")
 )
 
+var (
+	i18nCodeFallback atomic.Bool
+	strictTemplates  atomic.Bool
+)
+
+// SetI18nCodeFallback enables or disables the code fallback for untranslated messages, useful in early development
+// when translations lag behind code. When enabled, templates may be created from i18n.Messages without Other,
+// and if such a message cannot be resolved from the bundle, the rendered message is the error code
+// instead of an error, so missing translations are visible but not fatal.
+func SetI18nCodeFallback(enabled bool) {
+	i18nCodeFallback.Store(enabled)
+}
+
+// SetStrictTemplates makes templates created afterwards by NewI18nErrTmpl fail to render when they reference
+// a key missing from the template data, as if WithMissingKeyError was called on each of them,
+// so "<no value>" never ships to users. It is disabled by default for backward compatibility.
+func SetStrictTemplates(enabled bool) {
+	strictTemplates.Store(enabled)
+}
+
 // I18nErrTmpl is an i18n template that can render multiple I18nErr instances.
 type I18nErrTmpl struct {
-	base Err
-	i18n *i18n.Message
-	tmpl *template.Template
+	base   Err
+	i18n   *i18n.Message
+	tmpl   *template.Template
+	bundle *i18n.Bundle
+	logger *slog.Logger
+	// Template option for missing map keys, empty for the default, see WithMissingKeyError
+	missingKey string
 }
 
 // I18nErr is the error interface with i18n support.
@@ -30,6 +60,38 @@ type I18nErr interface {
 	GetRenderedData() any
 }
 
+// The optional interfaces below are implemented by Si18nerr and the types embedding it.
+// Other I18nErr implementations may implement them, the functions using them fall back to defaults otherwise.
+
+// LocaleTagged is implemented by I18nErrs knowing the locale they were rendered for, see LocaleOf.
+type LocaleTagged interface {
+	// GetLocale returns the BCP 47 tag of the locale the message was rendered for, "und" if undetermined
+	GetLocale() string
+}
+
+// Localizable is implemented by I18nErrs resolving their own localized code, title and message, see Localize.
+type Localizable interface {
+	// Localize resolves the code, localized title and localized message in one call
+	Localize(loc *i18n.Localizer, data any) (ErrCode, string, string, error)
+}
+
+// LocaleOf returns the locale of err if it implements LocaleTagged, otherwise "und".
+func LocaleOf(err I18nErr) string {
+	if l, ok := err.(LocaleTagged); ok {
+		return l.GetLocale()
+	}
+	return language.Und.String()
+}
+
+// Localize resolves the code, the short title and the detailed message of err localized by loc
+// with err's Localize method if it implements Localizable, otherwise like Si18nerr.Localize does.
+func Localize(err I18nErr, loc *i18n.Localizer, data any) (ErrCode, string, string, error) {
+	if l, ok := err.(Localizable); ok {
+		return l.Localize(loc, data)
+	}
+	return localizeI18nErr(err, loc, data)
+}
+
 // Si18nerr is the concrete implementation of I18nErr (rendered error).
 //
 //nolint:errname // ignore
@@ -38,26 +100,64 @@ type Si18nerr struct {
 
 	i18n         *i18n.Message
 	renderedData any
+	locale       string
 }
 
 // NewI18nErrTmpl creates an I18nErrTmpl from i18n.Message.
 // The i18n.ID will be used as the error code.
-// The i18n.Other will be parsed as a Go template.
+// The i18n.Other will be parsed as a Go template, in which the localNum and localDate functions
+// format numbers and dates, for an undetermined locale by Render and for the target locale by RenderLocalized.
+// Keys missing from map data are rendered as "<no value>", see WithMissingKeyError and SetStrictTemplates.
 func NewI18nErrTmpl(base Err, i18n *i18n.Message) (*I18nErrTmpl, error) {
 	if i18n.Other == "" {
-		return nil, ErrI18nMessageOtherMissing
+		if !i18nCodeFallback.Load() {
+			return nil, ErrI18nMessageOtherMissing
+		}
+		return &I18nErrTmpl{base: base, i18n: i18n}, nil
 	}
 
-	tmpl, err := template.New(i18n.ID).Parse(i18n.Other)
+	tmpl, err := template.New(i18n.ID).Funcs(localeFuncs(language.Und)).Parse(i18n.Other)
 	if err != nil {
 		return nil, err
 	}
 
-	return &I18nErrTmpl{
+	t := &I18nErrTmpl{
 		base: base,
 		i18n: i18n,
 		tmpl: tmpl,
-	}, nil
+	}
+	if strictTemplates.Load() {
+		t.WithMissingKeyError()
+	}
+	return t, nil
+}
+
+// NewI18nErrTmplWithBundle creates an I18nErrTmpl like NewI18nErrTmpl that keeps bundle for RenderLocalized.
+func NewI18nErrTmplWithBundle(base Err, i18n *i18n.Message, bundle *i18n.Bundle) (*I18nErrTmpl, error) {
+	tmpl, err := NewI18nErrTmpl(base, i18n)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.bundle = bundle
+	return tmpl, nil
+}
+
+// WithLogger sets the logger used to warn when RenderLocalizedWithFallback falls back, and returns the template.
+// It should be called when the template is constructed, e.g. MustNewI18nErrTmpl(...).WithLogger(logger).
+func (t *I18nErrTmpl) WithLogger(logger *slog.Logger) *I18nErrTmpl {
+	t.logger = logger
+	return t
+}
+
+// WithMissingKeyError makes rendering fail with an error when the template references a key missing from
+// the template data, instead of rendering "<no value>", and returns the template.
+// It should be called when the template is constructed, like WithLogger.
+func (t *I18nErrTmpl) WithMissingKeyError() *I18nErrTmpl {
+	t.missingKey = "missingkey=error"
+	if t.tmpl != nil {
+		t.tmpl.Option(t.missingKey)
+	}
+	return t
 }
 
 // MustNewI18nErrTmpl creates an I18nErrTmpl and panics on error.
@@ -70,32 +170,163 @@ func MustNewI18nErrTmpl(base Err, i18n *i18n.Message) *I18nErrTmpl {
 }
 
 // Render creates a new I18nErr with the template executed using templateData.
+// templateData may be a struct whose fields are keyed by their optional i18n tag, e.g. `i18n:"Name"`.
 func (t *I18nErrTmpl) Render(templateData any) (I18nErr, error) {
+	if t.codeFallback() {
+		return t.newI18nErr(string(t.code()), templateData, language.Und), nil
+	}
 	if t.tmpl == nil {
 		return nil, ErrI18nTemplateMissing
 	}
 
 	var buf bytes.Buffer
-	if err := t.tmpl.Execute(&buf, templateData); err != nil {
+	if err := t.tmpl.Execute(&buf, templateDataOf(templateData)); err != nil {
+		return nil, err
+	}
+
+	return t.newI18nErr(buf.String(), templateData, language.Und), nil
+}
+
+// RenderMap is like Render with map data, whose keys are referenced by the template, e.g. {{.Name}}.
+func (t *I18nErrTmpl) RenderMap(data map[string]any) (I18nErr, error) {
+	return t.Render(data)
+}
+
+// RenderLocalized creates a new I18nErr with the message localized by loc using templateData,
+// so plural rules and locale-specific messages of loc's bundle are applied.
+// The i18n.Message of the template is used as the default message.
+// Numbers and dates formatted with localNum and localDate are formatted for the locale of the message.
+// If loc is nil, a localizer for the default language of the template's bundle is used,
+// or Render if the template has no bundle.
+func (t *I18nErrTmpl) RenderLocalized(loc *i18n.Localizer, templateData any) (I18nErr, error) {
+	if loc == nil {
+		if t.bundle == nil {
+			return t.Render(templateData)
+		}
+		loc = i18n.NewLocalizer(t.bundle)
+	}
+
+	// Resolve the locale of the message without executing it first, so the template functions can format for it
+	_, tag, err := loc.LocalizeWithTag(&i18n.LocalizeConfig{
+		DefaultMessage: t.i18n,
+		TemplateParser: i18ntmpl.IdentityParser{},
+	})
+	if err != nil {
+		if t.codeFallback() {
+			return t.newI18nErr(string(t.code()), templateData, language.Und), nil
+		}
+		return nil, err
+	}
+	msg, err := loc.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: t.i18n,
+		TemplateData:   templateDataOf(templateData),
+		TemplateParser: &i18ntmpl.TextParser{Funcs: localeFuncs(tag), Option: t.missingKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.newI18nErr(msg, templateData, tag), nil
+}
+
+// codeFallback reports whether messages should fall back to the code, see SetI18nCodeFallback.
+func (t *I18nErrTmpl) codeFallback() bool {
+	return t.i18n.Other == "" && i18nCodeFallback.Load()
+}
+
+// code returns the code of the I18nErrs rendered by the template.
+func (t *I18nErrTmpl) code() ErrCode {
+	if strings.TrimSpace(t.i18n.ID) != "" {
+		return ErrCode(t.i18n.ID)
+	}
+	return t.base.GetCode()
+}
+
+// RenderLocalizedWithFallback is like RenderLocalized, but if loc cannot find the message for its locale,
+// the message is rendered from the raw i18n.Other template with Render instead of returning an error,
+// so the user always gets a human-readable message. A warning is logged if the template has a logger.
+func (t *I18nErrTmpl) RenderLocalizedWithFallback(loc *i18n.Localizer, templateData any) (I18nErr, error) {
+	ierr, err := t.RenderLocalized(loc, templateData)
+	var notFound *i18n.MessageNotFoundErr
+	if !errors.As(err, &notFound) {
+		return ierr, err
+	}
+
+	if t.logger != nil {
+		t.logger.Warn("i18n message not found, falling back to the default template",
+			slog.String("id", notFound.MessageID),
+			slog.String("locale", notFound.Tag.String()),
+		)
+	}
+	return t.Render(templateData)
+}
+
+// RenderLocalizedOrDefault creates a new I18nErr with the message translated for the locale of loc,
+// or if loc is nil or has no translation, for fallbackLang by the template's bundle.
+// Unlike RenderLocalizedWithFallback, the i18n.Message of the template is never used, so its source
+// can't leak to clients of another language: if there is no translation for either language,
+// an error wrapping ErrI18nTranslationMissing is returned. The language actually used is recorded
+// as the locale of the I18nErr, see LocaleOf.
+func (t *I18nErrTmpl) RenderLocalizedOrDefault(
+	loc *i18n.Localizer, templateData any, fallbackLang language.Tag,
+) (I18nErr, error) {
+	var notFound *i18n.MessageNotFoundErr
+	if loc != nil {
+		ierr, err := t.renderTranslation(loc, templateData)
+		if !errors.As(err, &notFound) {
+			return ierr, err
+		}
+	}
+	if t.bundle == nil {
+		return nil, fmt.Errorf("%w: %s has no translation and the template has no bundle",
+			ErrI18nTranslationMissing, t.i18n.ID)
+	}
+
+	ierr, err := t.renderTranslation(i18n.NewLocalizer(t.bundle, fallbackLang.String()), templateData)
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %w", ErrI18nTranslationMissing, err)
+	}
+	return ierr, err
+}
+
+// renderTranslation creates a new I18nErr with the message translated by loc's bundle for loc's locale,
+// without falling back to the template's i18n.Message.
+func (t *I18nErrTmpl) renderTranslation(loc *i18n.Localizer, templateData any) (I18nErr, error) {
+	// The bundle's default language may be used as fallback, it's an error like a missing translation
+	_, tag, err := loc.LocalizeWithTag(&i18n.LocalizeConfig{
+		MessageID:      t.i18n.ID,
+		TemplateParser: i18ntmpl.IdentityParser{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := loc.Localize(&i18n.LocalizeConfig{
+		MessageID:      t.i18n.ID,
+		TemplateData:   templateDataOf(templateData),
+		TemplateParser: &i18ntmpl.TextParser{Funcs: localeFuncs(tag), Option: t.missingKey},
+	})
+	if err != nil {
 		return nil, err
 	}
-	msg := buf.String()
+	return t.newI18nErr(msg, templateData, tag), nil
+}
 
+// newI18nErr creates a rendered I18nErr with msg as the message rendered for locale.
+func (t *I18nErrTmpl) newI18nErr(msg string, templateData any, locale language.Tag) I18nErr {
 	// Create the rendered error
 	err := NewErr(t.base, msg, "")
 	// Use i18n ID as code
-	if strings.TrimSpace(t.i18n.ID) != "" {
-		err.SetCode(t.i18n.ID)
-	}
+	err.SetCode(t.code())
 	ierr := &Si18nerr{
 		//nolint:errcheck // type must match
 		Serr:         *err.(*Serr),
 		i18n:         t.i18n,
 		renderedData: templateData,
+		locale:       locale.String(),
 	}
 	ierr.SetMetadata(templateData)
 
-	return ierr, nil
+	return ierr
 }
 
 func (t *I18nErrTmpl) GetI18n() *i18n.Message {
@@ -110,6 +341,21 @@ func (t *I18nErrTmpl) GetBase() Err {
 	return t.base
 }
 
+func (t *I18nErrTmpl) GetBundle() *i18n.Bundle {
+	return t.bundle
+}
+
+// Clone returns a mutable copy of the I18nErr.
+func (e *Si18nerr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *Si18nerr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	e.Serr.cloneInto(&c.Serr, &c, copies)
+	return &c
+}
+
 // GetI18n returns the original i18n message.
 func (e *Si18nerr) GetI18n() *i18n.Message {
 	return e.i18n
@@ -120,6 +366,48 @@ func (e *Si18nerr) GetRenderedData() any {
 	return e.renderedData
 }
 
+// GetLocale returns the BCP 47 tag of the locale the message was rendered for, "und" if undetermined.
+func (e *Si18nerr) GetLocale() string {
+	if e.locale == "" {
+		return language.Und.String()
+	}
+	return e.locale
+}
+
+// TitleMessageID returns the i18n message ID of the short title for errors with code, e.g. "NotFound.title".
+func TitleMessageID(code ErrCode) string {
+	return string(code) + ".title"
+}
+
+// Localize resolves the code, the short title and the detailed message of the error localized by loc.
+// The title is the message with ID TitleMessageID(code), falling back to the HTTP status text if it is missing.
+// The message is localized from the error's i18n message using data, or the rendered data if data is nil.
+func (e *Si18nerr) Localize(loc *i18n.Localizer, data any) (ErrCode, string, string, error) {
+	return localizeI18nErr(e, loc, data)
+}
+
+// localizeI18nErr localizes err by loc, see Si18nerr.Localize.
+func localizeI18nErr(err I18nErr, loc *i18n.Localizer, data any) (ErrCode, string, string, error) {
+	if data == nil {
+		data = err.GetRenderedData()
+	}
+	code := NamespacedCode(err)
+
+	title, lerr := loc.Localize(&i18n.LocalizeConfig{MessageID: TitleMessageID(code), TemplateData: data})
+	if lerr != nil || title == "" {
+		title = h.StatusText(err.GetHttpStatus())
+	}
+
+	if err.GetI18n() == nil {
+		return code, title, err.GetMessage(), nil
+	}
+	msg, lerr := loc.Localize(&i18n.LocalizeConfig{DefaultMessage: err.GetI18n(), TemplateData: data})
+	if lerr != nil {
+		return code, title, err.GetMessage(), lerr
+	}
+	return code, title, msg, nil
+}
+
 // NewI18nErr creates a rendered I18nErr from i18n.Message.
 // For simple messages without template variables (no "{{"), creates the error directly.
 // templateData is used only if the message contains template variables.
@@ -131,8 +419,8 @@ func NewI18nErr(base Err, i18n *i18n.Message, templateData any) (I18nErr, error)
 
 	// Fast path: no template variables, create error directly
 	if !strings.Contains(i18n.Other, "{{") {
-		code := i18n.ID
-		if strings.TrimSpace(code) == "" {
+		code := ErrCode(i18n.ID)
+		if strings.TrimSpace(i18n.ID) == "" {
 			code = base.GetCode()
 		}
 		return &Si18nerr{
@@ -0,0 +1,37 @@
+package werror
+
+import (
+	"io/fs"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// BundleFromFS creates an i18n.Bundle with lang as default language and loads the message files at paths in fsys,
+// e.g. an embed.FS. The language and format of each file are taken from its name, e.g. "active.zh-CN.toml".
+// TOML and JSON files are supported, other formats need an unmarshal function registered on the bundle
+// with RegisterUnmarshalFunc, so load them afterwards with LoadMessageFileFS.
+func BundleFromFS(fsys fs.FS, lang language.Tag, paths ...string) (*i18n.Bundle, error) {
+	bundle := i18n.NewBundle(lang)
+	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+	for _, path := range paths {
+		if _, err := bundle.LoadMessageFileFS(fsys, path); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+// DefaultBundle creates an i18n.Bundle with English as default language and the messages of the base Errs
+// of this package as English translations, with their codes as message IDs.
+// Translations for other languages can be added to it, e.g. with LoadMessageFileFS.
+func DefaultBundle() *i18n.Bundle {
+	bundle := i18n.NewBundle(language.English)
+	messages := make([]*i18n.Message, len(baseErrs))
+	for i, err := range baseErrs {
+		messages[i] = &i18n.Message{ID: string(err.GetCode()), Other: err.GetMessage()}
+	}
+	bundle.MustAddMessages(language.English, messages...)
+	return bundle
+}
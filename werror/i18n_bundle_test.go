@@ -0,0 +1,52 @@
+package werror
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+//go:embed testdata/i18n
+var testI18nFS embed.FS
+
+func TestBundleFromFS(t *testing.T) {
+	bundle, err := BundleFromFS(testI18nFS, language.English, "testdata/i18n/active.zh-CN.toml")
+	if err != nil {
+		t.Fatalf("BundleFromFS() failed: %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"BadRequest", "错误的请求"},
+		{"NotFound", "未找到"},
+	}
+	loc := i18n.NewLocalizer(bundle, "zh-CN")
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := loc.Localize(&i18n.LocalizeConfig{MessageID: tt.id})
+			if err != nil || got != tt.want {
+				t.Errorf("Localize(%s) = %q, %v, want %q", tt.id, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundleFromFS_MissingFile(t *testing.T) {
+	if _, err := BundleFromFS(testI18nFS, language.English, "testdata/i18n/missing.toml"); err == nil {
+		t.Error("BundleFromFS() with a missing file should fail")
+	}
+}
+
+func TestDefaultBundle(t *testing.T) {
+	loc := i18n.NewLocalizer(DefaultBundle(), "en")
+	for _, base := range baseErrs {
+		got, err := loc.Localize(&i18n.LocalizeConfig{MessageID: string(base.GetCode())})
+		if err != nil || got != base.GetMessage() {
+			t.Errorf("Localize(%s) = %q, %v, want %q", base.GetCode(), got, err, base.GetMessage())
+		}
+	}
+}
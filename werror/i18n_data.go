@@ -0,0 +1,54 @@
+package werror
+
+import (
+	"reflect"
+	"strings"
+)
+
+// i18nTag is the struct tag overriding the template key of a field, e.g. `i18n:"Name"`.
+// Fields tagged `i18n:"-"` are not available to templates.
+const i18nTag = "i18n"
+
+// templateDataOf returns the data to execute templates with for data.
+// Structs (or pointers to structs) with at least one field tagged i18n are converted to a map keyed by
+// the tag of each exported field, or its name if untagged. Other data is returned as is.
+func templateDataOf(data any) any {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return data
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !hasI18nTag(v.Type()) {
+		return data
+	}
+
+	typ := v.Type()
+	m := make(map[string]any, typ.NumField())
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tag, ok := field.Tag.Lookup(i18nTag); ok {
+			if tag = strings.TrimSpace(tag); tag == "-" {
+				continue
+			} else if tag != "" {
+				key = tag
+			}
+		}
+		m[key] = v.Field(i).Interface()
+	}
+	return m
+}
+
+func hasI18nTag(typ reflect.Type) bool {
+	for i := range typ.NumField() {
+		if _, ok := typ.Field(i).Tag.Lookup(i18nTag); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package werror
+
+import (
+	"text/template"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// defaultDateLayout is the date layout for locales without a known layout, ISO 8601.
+const defaultDateLayout = "2006-01-02"
+
+// dateLayouts are the short date layouts of common locales, matched with dateLayoutMatcher.
+var dateLayouts = []struct {
+	tag    language.Tag
+	layout string
+}{
+	{language.Und, defaultDateLayout},
+	{language.AmericanEnglish, "01/02/2006"},
+	{language.BritishEnglish, "02/01/2006"},
+	{language.German, "02.01.2006"},
+	{language.French, "02/01/2006"},
+	{language.Spanish, "02/01/2006"},
+	{language.Italian, "02/01/2006"},
+	{language.Russian, "02.01.2006"},
+	{language.Chinese, "2006/01/02"},
+	{language.Japanese, "2006/01/02"},
+	{language.Korean, "2006. 01. 02."},
+}
+
+var dateLayoutMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(dateLayouts))
+	for i, l := range dateLayouts {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// localeFuncs returns the template functions for formatting values for tag:
+//   - localNum formats a number with the digit grouping and decimal separator of tag, e.g. 1,000.5 or 1.000,5
+//   - localDate formats a time.Time with the short date layout of tag, e.g. 01/02/2006 or 02.01.2006
+func localeFuncs(tag language.Tag) template.FuncMap {
+	printer := message.NewPrinter(tag)
+	return template.FuncMap{
+		"localNum": func(v any) string {
+			return printer.Sprint(number.Decimal(v))
+		},
+		"localDate": func(t time.Time) string {
+			return t.Format(dateLayout(tag))
+		},
+	}
+}
+
+// dateLayout returns the short date layout of the locale best matching tag.
+func dateLayout(tag language.Tag) string {
+	_, i, conf := dateLayoutMatcher.Match(tag)
+	if conf == language.No {
+		return defaultDateLayout
+	}
+	return dateLayouts[i].layout
+}
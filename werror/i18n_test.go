@@ -1,12 +1,16 @@
 package werror
 
 import (
+	"bytes"
 	"errors"
+	"log/slog"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 )
 
 func TestNewI18nErrTmpl(t *testing.T) {
@@ -202,7 +206,7 @@ func TestI18nErrTmpl_Render(t *testing.T) {
 		i18nMsg      *i18n.Message
 		templateData any
 		wantMsg      string
-		wantCode     string
+		wantCode     ErrCode
 		wantErr      bool
 	}{
 		{
@@ -351,7 +355,7 @@ func TestNewI18nErr(t *testing.T) {
 		base       Err
 		i18n       *i18n.Message
 		wantErr    bool
-		wantCode   string
+		wantCode   ErrCode
 		wantMsg    string
 		wantStatus int
 	}{
@@ -587,3 +591,554 @@ func TestI18nErr_MetadataContainsTemplateData(t *testing.T) {
 		t.Errorf("GetMetadata() = %v, want %v", i18nErr.GetMetadata(), data)
 	}
 }
+
+func newTestBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.English, &i18n.Message{
+		ID:    "ItemsNotFound",
+		One:   "{{.Count}} item not found",
+		Other: "{{.Count}} items not found",
+	})
+	bundle.MustAddMessages(language.German, &i18n.Message{
+		ID:    "ItemsNotFound",
+		One:   "{{.Count}} Artikel nicht gefunden",
+		Other: "{{.Count}} Artikel nicht gefunden (mehrere)",
+	})
+	return bundle
+}
+
+func TestI18nErrTmpl_RenderLocalized(t *testing.T) {
+	bundle := newTestBundle(t)
+	tmpl, err := NewI18nErrTmplWithBundle(ErrNotFound, &i18n.Message{
+		ID:    "ItemsNotFound",
+		Other: "{{.Count}} items not found",
+	}, bundle)
+	if err != nil {
+		t.Fatalf("NewI18nErrTmplWithBundle() failed: %v", err)
+	}
+	if tmpl.GetBundle() != bundle {
+		t.Error("GetBundle() should return the bundle")
+	}
+
+	tests := []struct {
+		name    string
+		loc     *i18n.Localizer
+		data    map[string]any
+		wantMsg string
+	}{
+		{
+			name:    "English plural",
+			loc:     i18n.NewLocalizer(bundle, "en"),
+			data:    map[string]any{"Count": 3},
+			wantMsg: "3 items not found",
+		},
+		{
+			name:    "German",
+			loc:     i18n.NewLocalizer(bundle, "de"),
+			data:    map[string]any{"Count": 3},
+			wantMsg: "3 Artikel nicht gefunden (mehrere)",
+		},
+		{
+			name:    "Nil localizer uses bundle default language",
+			loc:     nil,
+			data:    map[string]any{"Count": 2},
+			wantMsg: "2 items not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.RenderLocalized(tt.loc, tt.data)
+			if err != nil {
+				t.Fatalf("RenderLocalized() unexpected error = %v", err)
+			}
+			if got.GetMessage() != tt.wantMsg {
+				t.Errorf("GetMessage() = %v, want %v", got.GetMessage(), tt.wantMsg)
+			}
+			if got.GetCode() != "ItemsNotFound" {
+				t.Errorf("GetCode() = %v, want ItemsNotFound", got.GetCode())
+			}
+			if got.GetHttpStatus() != 404 {
+				t.Errorf("GetHttpStatus() = %v, want 404", got.GetHttpStatus())
+			}
+			if !reflect.DeepEqual(got.GetRenderedData(), tt.data) {
+				t.Errorf("GetRenderedData() = %v, want %v", got.GetRenderedData(), tt.data)
+			}
+		})
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedWithoutBundle(t *testing.T) {
+	tmpl := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} not found"})
+
+	got, err := tmpl.RenderLocalized(nil, map[string]string{"Name": "Alice"})
+	if err != nil {
+		t.Fatalf("RenderLocalized() unexpected error = %v", err)
+	}
+	if got.GetMessage() != "User Alice not found" {
+		t.Errorf("GetMessage() = %v, want 'User Alice not found'", got.GetMessage())
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedFormatting(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.AmericanEnglish, &i18n.Message{
+		ID:    "PaymentFailed",
+		Other: "Payment of {{.Amount | localNum}} failed on {{.When | localDate}}",
+	})
+	bundle.MustAddMessages(language.German, &i18n.Message{
+		ID:    "PaymentFailed",
+		Other: "Zahlung von {{.Amount | localNum}} am {{.When | localDate}} fehlgeschlagen",
+	})
+	tmpl, err := NewI18nErrTmplWithBundle(ErrBadRequest, &i18n.Message{
+		ID:    "PaymentFailed",
+		Other: "Payment of {{.Amount | localNum}} failed on {{.When | localDate}}",
+	}, bundle)
+	if err != nil {
+		t.Fatalf("NewI18nErrTmplWithBundle() failed: %v", err)
+	}
+	data := map[string]any{"Amount": 1234.5, "When": time.Date(2024, time.March, 7, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		lang    string
+		wantMsg string
+	}{
+		{"en-US", "Payment of 1,234.5 failed on 03/07/2024"},
+		{"de", "Zahlung von 1.234,5 am 07.03.2024 fehlgeschlagen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got, err := tmpl.RenderLocalized(i18n.NewLocalizer(bundle, tt.lang), data)
+			if err != nil {
+				t.Fatalf("RenderLocalized() unexpected error = %v", err)
+			}
+			if got.GetMessage() != tt.wantMsg {
+				t.Errorf("GetMessage() = %v, want %v", got.GetMessage(), tt.wantMsg)
+			}
+		})
+	}
+
+	rendered, err := tmpl.Render(data)
+	if err != nil {
+		t.Fatalf("Render() unexpected error = %v", err)
+	}
+	if want := "Payment of 1,234.5 failed on 2024-03-07"; rendered.GetMessage() != want {
+		t.Errorf("Render() message = %v, want %v", rendered.GetMessage(), want)
+	}
+}
+
+func TestSi18nerr_GetLocale(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.AmericanEnglish, &i18n.Message{ID: "UserNotFound", Other: "User not found"})
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "UserNotFound", Other: "Benutzer nicht gefunden"})
+	msg := &i18n.Message{ID: "UserNotFound", Other: "User not found"}
+	tmpl, err := NewI18nErrTmplWithBundle(ErrNotFound, msg, bundle)
+	if err != nil {
+		t.Fatalf("NewI18nErrTmplWithBundle() failed: %v", err)
+	}
+
+	rendered, err := tmpl.Render(nil)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if LocaleOf(rendered) != "und" {
+		t.Errorf("Render() LocaleOf() = %v, want und", LocaleOf(rendered))
+	}
+
+	simple := MustNewI18nErr(ErrNotFound, msg, nil)
+	if LocaleOf(simple) != "und" {
+		t.Errorf("NewI18nErr() LocaleOf() = %v, want und", LocaleOf(simple))
+	}
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{lang: "en-US", want: "en-US"},
+		{lang: "de", want: "de"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			localized, err := tmpl.RenderLocalized(i18n.NewLocalizer(bundle, tt.lang), nil)
+			if err != nil {
+				t.Fatalf("RenderLocalized() failed: %v", err)
+			}
+			if LocaleOf(localized) != tt.want {
+				t.Errorf("RenderLocalized() LocaleOf() = %v, want %v", LocaleOf(localized), tt.want)
+			}
+		})
+	}
+}
+
+func TestSi18nerr_Localize(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.English,
+		&i18n.Message{ID: TitleMessageID("UserNotFound"), Other: "User not found"},
+		&i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} does not exist"},
+	)
+	bundle.MustAddMessages(language.German,
+		&i18n.Message{ID: TitleMessageID("UserNotFound"), Other: "Benutzer nicht gefunden"},
+		&i18n.Message{ID: "UserNotFound", Other: "Benutzer {{.Name}} existiert nicht"},
+	)
+
+	ierr := MustNewI18nErr(ErrNotFound, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "User {{.Name}} does not exist",
+	}, map[string]string{"Name": "Alice"})
+
+	tests := []struct {
+		name      string
+		lang      string
+		data      any
+		wantTitle string
+		wantMsg   string
+	}{
+		{
+			name:      "English with rendered data",
+			lang:      "en",
+			wantTitle: "User not found",
+			wantMsg:   "User Alice does not exist",
+		},
+		{
+			name:      "German with new data",
+			lang:      "de",
+			data:      map[string]string{"Name": "Bob"},
+			wantTitle: "Benutzer nicht gefunden",
+			wantMsg:   "Benutzer Bob existiert nicht",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, title, msg, err := Localize(ierr, i18n.NewLocalizer(bundle, tt.lang), tt.data)
+			if err != nil {
+				t.Fatalf("Localize() unexpected error = %v", err)
+			}
+			if code != "UserNotFound" {
+				t.Errorf("code = %v, want UserNotFound", code)
+			}
+			if title != tt.wantTitle {
+				t.Errorf("title = %v, want %v", title, tt.wantTitle)
+			}
+			if msg != tt.wantMsg {
+				t.Errorf("message = %v, want %v", msg, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSi18nerr_LocalizeTitleFallback(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	ierr := MustNewI18nErr(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}, nil)
+
+	_, title, msg, err := Localize(ierr, i18n.NewLocalizer(bundle, "en"), nil)
+	if err != nil {
+		t.Fatalf("Localize() unexpected error = %v", err)
+	}
+	if title != "Not Found" {
+		t.Errorf("title = %v, want the status text 'Not Found'", title)
+	}
+	if msg != "User not found" {
+		t.Errorf("message = %v, want 'User not found'", msg)
+	}
+}
+
+// plainI18nErr implements I18nErr only, none of the optional interfaces.
+type plainI18nErr struct { //nolint:errname // test
+	*plainErr
+
+	msg *i18n.Message
+}
+
+func (e *plainI18nErr) GetI18n() *i18n.Message { return e.msg }
+func (e *plainI18nErr) GetRenderedData() any   { return map[string]string{"Name": "Alice"} }
+
+func TestI18nOptionalInterfaces_Fallbacks(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.German,
+		&i18n.Message{ID: TitleMessageID("Teapot"), Other: "Teekanne"},
+		&i18n.Message{ID: "Teapot", Other: "{{.Name}} ist eine Teekanne"},
+	)
+	plain := &plainI18nErr{
+		plainErr: &plainErr{code: "Teapot", msg: "Alice is a teapot"},
+		msg:      &i18n.Message{ID: "Teapot", Other: "{{.Name}} is a teapot"},
+	}
+
+	if got := LocaleOf(plain); got != "und" {
+		t.Errorf("LocaleOf() = %v, want und", got)
+	}
+	code, title, msg, err := Localize(plain, i18n.NewLocalizer(bundle, "de"), nil)
+	if err != nil || code != "Teapot" || title != "Teekanne" || msg != "Alice ist eine Teekanne" {
+		t.Errorf("Localize() = %v, %q, %q, %v, want Teapot, Teekanne, Alice ist eine Teekanne, nil",
+			code, title, msg, err)
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedWithFallback(t *testing.T) {
+	// The bundle knows German, but has no message for the template
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "Other", Other: "Etwas anderes"})
+	loc := i18n.NewLocalizer(bundle, "de")
+
+	var logs bytes.Buffer
+	tmpl := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "User {{.Name}} not found",
+	}).WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	data := map[string]string{"Name": "Alice"}
+
+	if _, err := tmpl.RenderLocalized(loc, data); err == nil {
+		t.Fatal("RenderLocalized() expected a message not found error, got nil")
+	}
+
+	got, err := tmpl.RenderLocalizedWithFallback(loc, data)
+	if err != nil {
+		t.Fatalf("RenderLocalizedWithFallback() unexpected error = %v", err)
+	}
+	if got.GetMessage() != "User Alice not found" {
+		t.Errorf("GetMessage() = %v, want 'User Alice not found'", got.GetMessage())
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "id=UserNotFound") {
+		t.Errorf("RenderLocalizedWithFallback() should log a warning, got %q", logs.String())
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedWithFallbackFound(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "UserNotFound", Other: "Benutzer nicht gefunden"})
+	tmpl := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"})
+
+	got, err := tmpl.RenderLocalizedWithFallback(i18n.NewLocalizer(bundle, "de"), nil)
+	if err != nil {
+		t.Fatalf("RenderLocalizedWithFallback() unexpected error = %v", err)
+	}
+	if got.GetMessage() != "Benutzer nicht gefunden" {
+		t.Errorf("GetMessage() = %v, want 'Benutzer nicht gefunden'", got.GetMessage())
+	}
+}
+
+func TestSetI18nCodeFallback(t *testing.T) {
+	SetI18nCodeFallback(true)
+	t.Cleanup(func() { SetI18nCodeFallback(false) })
+
+	tmpl, err := NewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "OrderNotFound"})
+	if err != nil {
+		t.Fatalf("NewI18nErrTmpl() unexpected error = %v", err)
+	}
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "Other", Other: "Etwas anderes"})
+
+	tests := []struct {
+		name   string
+		render func() (I18nErr, error)
+	}{
+		{"Render", func() (I18nErr, error) { return tmpl.Render(nil) }},
+		{"RenderLocalized", func() (I18nErr, error) {
+			return tmpl.RenderLocalized(i18n.NewLocalizer(bundle, "de"), nil)
+		}},
+		{"RenderLocalizedWithFallback", func() (I18nErr, error) {
+			return tmpl.RenderLocalizedWithFallback(i18n.NewLocalizer(bundle, "en"), nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.render()
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			if got.GetMessage() != "OrderNotFound" || got.GetCode() != "OrderNotFound" {
+				t.Errorf("GetMessage(), GetCode() = %q, %q, want the code as message", got.GetMessage(), got.GetCode())
+			}
+		})
+	}
+
+	// A translation in the bundle still wins
+	bundle.MustAddMessages(language.German, &i18n.Message{ID: "OrderNotFound", Other: "Bestellung nicht gefunden"})
+	got, err := tmpl.RenderLocalized(i18n.NewLocalizer(bundle, "de"), nil)
+	if err != nil || got.GetMessage() != "Bestellung nicht gefunden" {
+		t.Errorf("RenderLocalized() = %v, %v, want the translation", got, err)
+	}
+
+	SetI18nCodeFallback(false)
+	_, err = NewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "OrderNotFound"})
+	if !errors.Is(err, ErrI18nMessageOtherMissing) {
+		t.Errorf("NewI18nErrTmpl() without fallback error = %v, want %v", err, ErrI18nMessageOtherMissing)
+	}
+}
+
+func TestI18nErrTmpl_RenderStructData(t *testing.T) {
+	type user struct {
+		FullName string `i18n:"Name"`
+		Age      int
+		Password string `i18n:"-"`
+	}
+	tmpl := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "User {{.Name}} ({{.Age}}) not found{{with .Password}}: {{.}}{{end}}",
+	})
+	data := user{FullName: "Alice", Age: 30, Password: "secret"}
+
+	for _, d := range []any{data, &data} {
+		got, err := tmpl.Render(d)
+		if err != nil {
+			t.Fatalf("Render() unexpected error = %v", err)
+		}
+		if want := "User Alice (30) not found"; got.GetMessage() != want {
+			t.Errorf("GetMessage() = %v, want %v", got.GetMessage(), want)
+		}
+		if got.GetRenderedData() != d {
+			t.Errorf("GetRenderedData() = %v, want the original data %v", got.GetRenderedData(), d)
+		}
+	}
+
+	got, err := tmpl.RenderMap(map[string]any{"Name": "Bob", "Age": 40})
+	if err != nil {
+		t.Fatalf("RenderMap() unexpected error = %v", err)
+	}
+	if want := "User Bob (40) not found"; got.GetMessage() != want {
+		t.Errorf("RenderMap() message = %v, want %v", got.GetMessage(), want)
+	}
+}
+
+func TestI18nErrTmpl_WithMissingKeyError(t *testing.T) {
+	msg := &i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} not found"}
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.English, msg)
+	data := map[string]any{"Age": 30}
+
+	lenient := MustNewI18nErrTmpl(ErrNotFound, msg)
+	got, err := lenient.RenderMap(data)
+	if err != nil {
+		t.Fatalf("RenderMap() unexpected error = %v", err)
+	}
+	if want := "User <no value> not found"; got.GetMessage() != want {
+		t.Errorf("GetMessage() = %v, want %v", got.GetMessage(), want)
+	}
+
+	strict, err := NewI18nErrTmplWithBundle(ErrNotFound, msg, bundle)
+	if err != nil {
+		t.Fatalf("NewI18nErrTmplWithBundle() failed: %v", err)
+	}
+	strict.WithMissingKeyError()
+	if _, err := strict.RenderMap(data); err == nil {
+		t.Error("RenderMap() should fail for a missing key")
+	}
+	if _, err := strict.RenderLocalized(i18n.NewLocalizer(bundle, "en"), data); err == nil {
+		t.Error("RenderLocalized() should fail for a missing key")
+	}
+	if _, err := strict.RenderMap(map[string]any{"Name": "Alice"}); err != nil {
+		t.Errorf("RenderMap() unexpected error = %v", err)
+	}
+}
+
+func TestSetStrictTemplates(t *testing.T) {
+	msg := &i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} not found"}
+
+	tests := []struct {
+		name    string
+		strict  bool
+		data    any
+		wantMsg string
+		wantErr bool
+	}{
+		{name: "Lenient with empty data", data: nil, wantMsg: "User <no value> not found"},
+		{name: "Lenient with missing key", data: map[string]any{}, wantMsg: "User <no value> not found"},
+		{name: "Strict with empty data", strict: true, data: nil, wantErr: true},
+		{name: "Strict with missing key", strict: true, data: map[string]any{}, wantErr: true},
+		{name: "Strict with key", strict: true, data: map[string]any{"Name": "Alice"}, wantMsg: "User Alice not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStrictTemplates(tt.strict)
+			t.Cleanup(func() { SetStrictTemplates(false) })
+			tmpl := MustNewI18nErrTmpl(ErrNotFound, msg)
+
+			got, err := tmpl.Render(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.GetMessage() != tt.wantMsg {
+				t.Errorf("GetMessage() = %v, want %v", got.GetMessage(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedOrDefault(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.MustAddMessages(language.German,
+		&i18n.Message{ID: "UserNotFound", Other: "Benutzer {{.Name}} nicht gefunden"})
+	bundle.MustAddMessages(language.French,
+		&i18n.Message{ID: "UserNotFound", Other: "Utilisateur {{.Name}} introuvable"})
+	tmpl, err := NewI18nErrTmplWithBundle(ErrNotFound, &i18n.Message{
+		ID:    "UserNotFound",
+		Other: "User {{.Name}} not found",
+	}, bundle)
+	if err != nil {
+		t.Fatalf("NewI18nErrTmplWithBundle() failed: %v", err)
+	}
+	data := map[string]any{"Name": "Alice"}
+
+	tests := []struct {
+		name       string
+		loc        *i18n.Localizer
+		fallback   language.Tag
+		wantMsg    string
+		wantLocale string
+		wantErr    bool
+	}{
+		{
+			name:       "Requested locale",
+			loc:        i18n.NewLocalizer(bundle, "de"),
+			fallback:   language.French,
+			wantMsg:    "Benutzer Alice nicht gefunden",
+			wantLocale: "de",
+		},
+		{
+			name:       "Fallback language",
+			loc:        i18n.NewLocalizer(bundle, "ja"),
+			fallback:   language.French,
+			wantMsg:    "Utilisateur Alice introuvable",
+			wantLocale: "fr",
+		},
+		{
+			name:       "Nil localizer",
+			fallback:   language.German,
+			wantMsg:    "Benutzer Alice nicht gefunden",
+			wantLocale: "de",
+		},
+		{
+			name:     "No translation",
+			loc:      i18n.NewLocalizer(bundle, "ja"),
+			fallback: language.English,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.RenderLocalizedOrDefault(tt.loc, data, tt.fallback)
+			if tt.wantErr {
+				if !errors.Is(err, ErrI18nTranslationMissing) {
+					t.Errorf("RenderLocalizedOrDefault() error = %v, want ErrI18nTranslationMissing", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderLocalizedOrDefault() unexpected error = %v", err)
+			}
+			if got.GetMessage() != tt.wantMsg || LocaleOf(got) != tt.wantLocale {
+				t.Errorf("RenderLocalizedOrDefault() = %q in %s, want %q in %s",
+					got.GetMessage(), LocaleOf(got), tt.wantMsg, tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestI18nErrTmpl_RenderLocalizedOrDefaultWithoutBundle(t *testing.T) {
+	tmpl := MustNewI18nErrTmpl(ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"})
+	if _, err := tmpl.RenderLocalizedOrDefault(nil, nil, language.English); !errors.Is(err, ErrI18nTranslationMissing) {
+		t.Errorf("RenderLocalizedOrDefault() error = %v, want ErrI18nTranslationMissing", err)
+	}
+}
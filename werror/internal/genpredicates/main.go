@@ -0,0 +1,81 @@
+// Command genpredicates generates predicates.go with an Is<Code> predicate for each base Err code in error.go.
+// Run it with go generate in the werror directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	src = "error.go"
+	dst = "predicates.go"
+)
+
+var tmpl = template.Must(template.New(dst).Parse(`// Code generated by genpredicates from error.go; DO NOT EDIT.
+
+package werror
+{{range .}}
+// Is{{.}} reports whether err wraps an Err with code Code{{.}}.
+func Is{{.}}(err error) bool {
+	return IsErrOf(err, Code{{.}})
+}
+{{end}}`))
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "genpredicates:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	file, err := parser.ParseFile(token.NewFileSet(), src, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, codeNames(file)); err != nil {
+		return err
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, out, 0o644) //nolint:gosec // generated source file
+}
+
+// codeNames returns the names of the ErrCode constants declared in file without their Code prefix,
+// e.g. "NotFound" for CodeNotFound, in declaration order.
+func codeNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			if typ, ok := vs.Type.(*ast.Ident); !ok || typ.Name != "ErrCode" {
+				continue
+			}
+			for _, name := range vs.Names {
+				if code, ok := strings.CutPrefix(name.Name, "Code"); ok && code != "" {
+					names = append(names, code)
+				}
+			}
+		}
+	}
+	return names
+}
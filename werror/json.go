@@ -0,0 +1,132 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	h "net/http"
+)
+
+// CodeCyclicReference is the code of the placeholder serialized instead of a sub-error that is its own ancestor.
+const CodeCyclicReference ErrCode = "CyclicReference"
+
+// serrJSON is the JSON form of a Serr.
+type serrJSON struct {
+	Code      ErrCode `json:"code"`
+	Message   string  `json:"message"`
+	SubErrors []any   `json:"subErrors,omitempty"`
+	Metadata  any     `json:"metadata,omitempty"`
+}
+
+// cyclicRefJSON is the JSON form of a sub-error that is its own ancestor.
+type cyclicRefJSON struct {
+	Code ErrCode `json:"code"`
+}
+
+// jsonValuer is implemented by *Serr and the Err types embedding it to be serialized with cycle detection.
+type jsonValuer interface {
+	// jsonValue returns the value to serialize the Err as,
+	// ancestors are the Serrs of the Errs that contain it as a (transitive) sub-error.
+	jsonValue(ancestors map[*Serr]bool) any
+}
+
+// MarshalJSON serializes the Err with its sub-errors. A sub-error that is its own ancestor, e.g. added to itself
+// with AddSubErrors, is serialized as a {"code":"CyclicReference"} placeholder instead of recursing forever.
+func (e *Serr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *Serr) jsonValue(ancestors map[*Serr]bool) any {
+	return e.toJSON(ancestors)
+}
+
+func (e *Serr) toJSON(ancestors map[*Serr]bool) serrJSON {
+	v := serrJSON{Code: e.Code, Message: e.Message, Metadata: e.Metadata}
+	if e.SubErrors == nil {
+		return v
+	}
+
+	ancestors[e] = true
+	defer delete(ancestors, e)
+	v.SubErrors = make([]any, len(e.SubErrors))
+	for i, sub := range e.SubErrors {
+		v.SubErrors[i] = subErrJSON(sub, ancestors)
+	}
+	return v
+}
+
+// subErrJSON returns the value to serialize sub as, see jsonValuer.
+func subErrJSON(sub Err, ancestors map[*Serr]bool) any {
+	s, ok := sub.(serrEmbedder)
+	if !ok || s.serr() == nil {
+		return sub
+	}
+	if ancestors[s.serr()] {
+		return cyclicRefJSON{Code: CodeCyclicReference}
+	}
+	if v, ok := sub.(jsonValuer); ok {
+		return v.jsonValue(ancestors)
+	}
+	return sub
+}
+
+// ErrEnvelopeCodeMissing is returned by ParseError for a JSON error envelope without code.
+var ErrEnvelopeCodeMissing = errors.New("error envelope has no code")
+
+// errEnvelope is the JSON form of an Err as received from another service, see ParseError.
+type errEnvelope struct {
+	Code      ErrCode           `json:"code"`
+	Message   string            `json:"message"`
+	SubErrors []json.RawMessage `json:"subErrors"`
+	Metadata  any               `json:"metadata"`
+}
+
+// ParseError rehydrates an Err from its JSON envelope, e.g. received from an upstream service,
+// with its sub-errors recursively. The JSON of an Err has no status, so it is recovered from the code:
+// if the code is registered (see LookupByCode and RegisterBaseErr), or for a namespaced code like "order.NotFound"
+// its bare code is, the Err wraps that base Err and has its status, otherwise it has status 500.
+// The Errs are remote (see OriginRemote), and take the base message if the envelope has none.
+func ParseError(data []byte) (Err, error) {
+	var envelope errEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	return envelope.toErr()
+}
+
+func (env *errEnvelope) toErr() (Err, error) {
+	if env.Code == "" {
+		return nil, ErrEnvelopeCodeMissing
+	}
+
+	ns, bare := splitNamespace(env.Code)
+	base, ok := LookupByCode(env.Code)
+	if !ok && ns != "" {
+		base, ok = LookupByCode(bare)
+	}
+	if !ok {
+		base = NewBaseErr(h.StatusInternalServerError, bare, env.Message)
+	}
+	msg := env.Message
+	if msg == "" {
+		msg = base.GetMessage()
+	}
+	err := &Serr{
+		error:      fmt.Errorf("%w: %s", base, msg),
+		HttpStatus: base.GetHttpStatus(),
+		Code:       env.Code,
+		Namespace:  ns,
+		Message:    msg,
+		Metadata:   env.Metadata,
+		Origin:     OriginRemote,
+	}
+
+	for _, data := range env.SubErrors {
+		sub, perr := ParseError(data)
+		if perr != nil {
+			return nil, perr
+		}
+		err.SubErrors = append(err.SubErrors, sub)
+	}
+	return err, nil
+}
@@ -0,0 +1,204 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestSerr_MarshalJSON(t *testing.T) {
+	shared := NewErr(ErrInvalidInput, "", "")
+	dag := NewErr(ErrBadRequest, "", "")
+	dag.AddSubErrors(shared, shared)
+
+	throttled := NewErr(ErrBadRequest, "", "")
+	throttled.AddSubErrors(NewThrottleErr(3, 2, 1))
+
+	self := NewErr(ErrBadRequest, "", "")
+	self.AddSubErrors(self)
+
+	outer := NewErr(ErrConflict, "", "")
+	inner := NewErr(ErrInvalidInput, "", "")
+	outer.AddSubErrors(inner)
+	inner.AddSubErrors(outer)
+
+	tests := []struct {
+		name string
+		err  Err
+		want string
+	}{
+		{
+			"No sub-errors",
+			ErrNotFound,
+			`{"code":"NotFound","message":"Not found"}`,
+		},
+		{
+			"Shared sub-error is not a cycle",
+			dag,
+			`{"code":"BadRequest","message":"Bad request","subErrors":[` +
+				`{"code":"InvalidInput","message":"Some request inputs are not valid"},` +
+				`{"code":"InvalidInput","message":"Some request inputs are not valid"}]}`,
+		},
+		{
+			"ThrottleErr sub-error keeps its fields",
+			throttled,
+			`{"code":"BadRequest","message":"Bad request","subErrors":[{"code":"TooManyRequests",` +
+				`"message":"Too many requests","retryAfterSeconds":3,"backoffMultiplier":2,"maxRetries":1}]}`,
+		},
+		{
+			"Self-referential",
+			self,
+			`{"code":"BadRequest","message":"Bad request","subErrors":[{"code":"CyclicReference"}]}`,
+		},
+		{
+			"Indirect cycle",
+			outer,
+			`{"code":"Conflict","message":"Conflict","subErrors":[{"code":"InvalidInput",` +
+				`"message":"Some request inputs are not valid","subErrors":[{"code":"CyclicReference"}]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("json.Marshal() unexpected error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantStatus int
+		wantCode   ErrCode
+		wantMsg    string
+		wantLeaves []ErrCode
+		wantErr    bool
+	}{
+		{
+			name:       "Registered code",
+			data:       `{"code":"NotFound","message":"User 42 not found"}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+			wantMsg:    "User 42 not found",
+		},
+		{
+			name:       "Registered code without message",
+			data:       `{"code":"Conflict"}`,
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeConflict,
+			wantMsg:    "Conflict",
+		},
+		{
+			name:       "Unknown code",
+			data:       `{"code":"Boom","message":"Boom"}`,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "Boom",
+			wantMsg:    "Boom",
+		},
+		{
+			name: "Nested sub-errors",
+			data: `{"code":"BadRequest","message":"Bad request","subErrors":[` +
+				`{"code":"InvalidInput","message":"name","subErrors":[{"code":"PasswordTooWeak","message":"pw"}]},` +
+				`{"code":"Custom","message":"custom"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeBadRequest,
+			wantMsg:    "Bad request",
+			wantLeaves: []ErrCode{CodePasswordTooWeak, "Custom"},
+		},
+		{name: "Missing code", data: `{"message":"Bad request"}`, wantErr: true},
+		{name: "Sub-error missing code", data: `{"code":"BadRequest","subErrors":[{}]}`, wantErr: true},
+		{name: "Invalid JSON", data: `{"code":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseError([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.GetHttpStatus() != tt.wantStatus || got.GetCode() != tt.wantCode || got.GetMessage() != tt.wantMsg {
+				t.Errorf("ParseError() = %v %v %q, want %v %v %q", got.GetHttpStatus(), got.GetCode(),
+					got.GetMessage(), tt.wantStatus, tt.wantCode, tt.wantMsg)
+			}
+			if OriginOf(got) != OriginRemote {
+				t.Errorf("GetOrigin() = %v, want %v", OriginOf(got), OriginRemote)
+			}
+			if base, ok := LookupByCode(tt.wantCode); ok && !errors.Is(got, base) {
+				t.Errorf("errors.Is(got, %v) = false, want true", tt.wantCode)
+			}
+			var leaves []ErrCode
+			for _, sub := range got.(*Serr).FlatDetails() {
+				leaves = append(leaves, sub.GetCode())
+			}
+			if !slices.Equal(leaves, tt.wantLeaves) {
+				t.Errorf("leaf sub-error codes = %v, want %v", leaves, tt.wantLeaves)
+			}
+		})
+	}
+}
+
+func TestParseError_Namespaced(t *testing.T) {
+	got, err := ParseError([]byte(`{"code":"billing.NotFound","message":"Invoice not found"}`))
+	if err != nil {
+		t.Fatalf("ParseError() failed: %v", err)
+	}
+	if NamespacedCode(got) != "billing.NotFound" || got.GetCode() != CodeNotFound {
+		t.Errorf("ParseError() code = %v (%v), want billing.NotFound (NotFound)", NamespacedCode(got), got.GetCode())
+	}
+	if got.GetHttpStatus() != http.StatusNotFound || !errors.Is(got, ErrNotFound) {
+		t.Errorf("ParseError() = %v, want it derived from ErrNotFound", got)
+	}
+}
+
+func TestParseError_RoundTrip(t *testing.T) {
+	err := NewErr(ErrBadRequest, "", "")
+	err.AddSubErrors(NewErr(ErrInvalidInput, "name is required", ""))
+	err.SetMetadata(map[string]any{"requestId": "abc"})
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	parsed, perr := ParseError(data)
+	if perr != nil {
+		t.Fatalf("ParseError() failed: %v", perr)
+	}
+	if redata, _ := json.Marshal(parsed); string(redata) != string(data) {
+		t.Errorf("json.Marshal(ParseError()) = %s, want %s", redata, data)
+	}
+}
+
+func TestParseError_RoundTrip_RegisteredStatus(t *testing.T) {
+	errQuotaExceeded := NewBaseErr(http.StatusPaymentRequired, "QuotaExceeded", "Quota exceeded")
+	if err := RegisterBaseErr(errQuotaExceeded); err != nil {
+		t.Fatalf("RegisterBaseErr() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		code2ErrMapMu.Lock()
+		delete(Code2ErrMap, "QuotaExceeded")
+		code2ErrMapMu.Unlock()
+	})
+
+	data, jerr := json.Marshal(NewErr(errQuotaExceeded, "Quota of 10 projects exceeded", ""))
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	parsed, perr := ParseError(data)
+	if perr != nil {
+		t.Fatalf("ParseError() failed: %v", perr)
+	}
+	if parsed.GetHttpStatus() != http.StatusPaymentRequired || !errors.Is(parsed, errQuotaExceeded) {
+		t.Errorf("ParseError(%s) = %v (%d), want the registered base Err and its status",
+			data, parsed, parsed.GetHttpStatus())
+	}
+}
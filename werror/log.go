@@ -0,0 +1,49 @@
+package werror
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+)
+
+// StackedError is implemented by errors carrying the stack trace of where they were created.
+type StackedError interface {
+	error
+	StackTrace() string
+}
+
+// LogErr logs err at slog.LevelError with msg, the attrs "http_status", "error_code", "error_message" and
+// "severity" (see SeverityOf), the group "details" with the code and message of each sub-error if it has any,
+// "stack" if err wraps a StackedError, and extra. It does nothing if err is nil.
+func LogErr(logger *slog.Logger, err Err, msg string, extra ...slog.Attr) {
+	if err == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Int("http_status", err.GetHttpStatus()),
+		slog.String("error_code", string(err.GetCode())),
+		slog.String("error_message", err.GetMessage()),
+		slog.String("severity", SeverityOf(err).String()),
+	}
+	if subs := err.GetSubErrors(); len(subs) > 0 {
+		details := make([]any, 0, len(subs))
+		for i, sub := range subs {
+			if sub != nil {
+				details = append(details, slog.Group(strconv.Itoa(i),
+					slog.String("code", string(sub.GetCode())),
+					slog.String("message", sub.GetMessage()),
+				))
+			}
+		}
+		attrs = append(attrs, slog.Group("details", details...))
+	}
+	var serr StackedError
+	if errors.As(err, &serr) {
+		attrs = append(attrs, slog.String("stack", serr.StackTrace()))
+	}
+	attrs = append(attrs, extra...)
+
+	logger.LogAttrs(context.Background(), slog.LevelError, msg, attrs...)
+}
@@ -0,0 +1,75 @@
+package werror
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+type stackedErr struct {
+	Err
+}
+
+func (e stackedErr) StackTrace() string {
+	return "main.go:42"
+}
+
+func logErrJSON(t *testing.T, err Err, extra ...slog.Attr) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	LogErr(slog.New(slog.NewJSONHandler(&buf, nil)), err, "request failed", extra...)
+	if buf.Len() == 0 {
+		return nil
+	}
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	return record
+}
+
+func TestLogErr(t *testing.T) {
+	err := NewErr(ErrBadRequest, "Invalid user", "")
+	err.AddSubErrors(NewErr(ErrInvalidInput, "Name is required", ""))
+
+	got := logErrJSON(t, err, slog.String("request_id", "r-1"))
+	want := map[string]any{
+		"level":         "ERROR",
+		"msg":           "request failed",
+		"http_status":   float64(400),
+		"error_code":    "BadRequest",
+		"error_message": "Invalid user",
+		"severity":      "warning",
+		"details":       map[string]any{"0": map[string]any{"code": "InvalidInput", "message": "Name is required"}},
+		"request_id":    "r-1",
+	}
+	delete(got, "time")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LogErr() logged %v, want %v", got, want)
+	}
+}
+
+func TestLogErr_Stack(t *testing.T) {
+	got := logErrJSON(t, ErrNotFound)
+	if _, ok := got["details"]; ok {
+		t.Errorf("LogErr() logged details %v for an Err without sub-errors", got["details"])
+	}
+	if _, ok := got["stack"]; ok {
+		t.Errorf("LogErr() logged a stack %v for an Err without one", got["stack"])
+	}
+
+	got = logErrJSON(t, ToErr(fmt.Errorf("wrap: %w", stackedErr{Err: ErrNotFound})))
+	if got["stack"] != "main.go:42" {
+		t.Errorf("stack = %v, want main.go:42", got["stack"])
+	}
+}
+
+func TestLogErr_Nil(t *testing.T) {
+	if got := logErrJSON(t, nil); got != nil {
+		t.Errorf("LogErr(nil) logged %v, want nothing", got)
+	}
+}
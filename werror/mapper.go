@@ -0,0 +1,86 @@
+package werror
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+)
+
+// ErrCodeMapper maps errors, e.g. database driver errors, to Errs with registered predicates.
+// The zero value is ready to use, and it is safe for concurrent use.
+type ErrCodeMapper struct {
+	mu       sync.RWMutex
+	mappings []errMapping
+}
+
+type errMapping struct {
+	predicate func(error) bool
+	target    Err
+}
+
+// Register maps the errors predicate returns true for to target.
+// Predicates are checked in registration order and should use errors.As to find the error they recognize.
+func (m *ErrCodeMapper) Register(predicate func(error) bool, target Err) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings = append(m.mappings, errMapping{predicate: predicate, target: target})
+}
+
+// Map returns a new Err from err with the target of the first matching predicate as base,
+// or ErrInternalError if none matches. It returns nil if err is nil.
+func (m *ErrCodeMapper) Map(err error) Err {
+	if err == nil {
+		return nil
+	}
+	if target := m.lookup(err); target != nil {
+		return NewErrFromError(target, err)
+	}
+	return NewErrFromError(ErrInternalError, err)
+}
+
+// lookup returns the target of the first predicate matching err, nil if none matches.
+func (m *ErrCodeMapper) lookup(err error) Err {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, mapping := range m.mappings {
+		if mapping.predicate(err) {
+			return mapping.target
+		}
+	}
+	return nil
+}
+
+// DefaultMapper maps common standard library errors to Errs for ToErr:
+// context.DeadlineExceeded to ErrTimeout, context.Canceled to ErrClientClosedRequest (like ErrFromContext),
+// os.ErrDeadlineExceeded to ErrTimeout and sql.ErrNoRows to ErrResourceNotFound.
+// Extend it with RegisterMapping.
+var DefaultMapper = newDefaultMapper()
+
+func newDefaultMapper() *ErrCodeMapper {
+	m := &ErrCodeMapper{}
+	for _, mapping := range append(slices.Clone(contextErrBases), []struct {
+		sentinel error
+		base     Err
+	}{
+		{os.ErrDeadlineExceeded, ErrTimeout},
+		{sql.ErrNoRows, ErrResourceNotFound},
+	}...) {
+		m.Register(isSentinel(mapping.sentinel), mapping.base)
+	}
+	return m
+}
+
+// RegisterMapping makes ToErr convert errors wrapping sentinel (see errors.Is) to Errs with base as base.
+// Mappings registered earlier, including those of DefaultMapper, take precedence.
+func RegisterMapping(sentinel error, base Err) {
+	DefaultMapper.Register(isSentinel(sentinel), base)
+}
+
+// isSentinel returns a predicate matching errors wrapping sentinel.
+func isSentinel(sentinel error) func(error) bool {
+	return func(err error) bool {
+		return errors.Is(err, sentinel)
+	}
+}
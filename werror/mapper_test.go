@@ -0,0 +1,92 @@
+package werror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+type testDriverErr struct {
+	Number int
+}
+
+func (e *testDriverErr) Error() string {
+	return fmt.Sprintf("driver error %d", e.Number)
+}
+
+func driverErrNumber(n int) func(error) bool {
+	return func(err error) bool {
+		var derr *testDriverErr
+		return errors.As(err, &derr) && derr.Number == n
+	}
+}
+
+func TestErrCodeMapper(t *testing.T) {
+	var m ErrCodeMapper
+	m.Register(driverErrNumber(1062), ErrConflict)
+	m.Register(driverErrNumber(1205), ErrTimeout)
+	m.Register(driverErrNumber(1205), ErrServiceUnavailable) // Shadowed by the previous one
+
+	tests := []struct {
+		name string
+		err  error
+		want Err
+	}{
+		{"nil", nil, nil},
+		{"duplicate key", &testDriverErr{Number: 1062}, ErrConflict},
+		{"wrapped", fmt.Errorf("insert user: %w", &testDriverErr{Number: 1205}), ErrTimeout},
+		{"unregistered number", &testDriverErr{Number: 1}, ErrInternalError},
+		{"other error", errors.New("boom"), ErrInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Map(tt.err)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("Map() = %v, want nil", got)
+				}
+				return
+			}
+			if got.GetCode() != tt.want.GetCode() {
+				t.Errorf("Map() = %v, want code %v", got, tt.want.GetCode())
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Map() = %v, should wrap %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestToErr_DefaultMapper(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	RegisterMapping(errQuota, ErrTooManyRequests)
+	RegisterMapping(sql.ErrNoRows, ErrNotFound) // Shadowed by DefaultMapper
+	t.Cleanup(func() { DefaultMapper = newDefaultMapper() })
+
+	tests := []struct {
+		name string
+		err  error
+		want Err
+	}{
+		{"context.DeadlineExceeded", context.DeadlineExceeded, ErrTimeout},
+		{"context.Canceled", fmt.Errorf("read body: %w", context.Canceled), ErrClientClosedRequest},
+		{"os.ErrDeadlineExceeded", fmt.Errorf("read: %w", os.ErrDeadlineExceeded), ErrTimeout},
+		{"sql.ErrNoRows", fmt.Errorf("get user: %w", sql.ErrNoRows), ErrResourceNotFound},
+		{"registered sentinel", fmt.Errorf("upload: %w", errQuota), ErrTooManyRequests},
+		{"unmapped error", errors.New("boom"), ErrInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToErr(tt.err)
+			if got.GetCode() != tt.want.GetCode() || got.GetHttpStatus() != tt.want.GetHttpStatus() {
+				t.Errorf("ToErr() = %v, want code %v", got, tt.want.GetCode())
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("ToErr() = %v, should wrap %v", got, tt.err)
+			}
+		})
+	}
+}
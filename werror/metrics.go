@@ -0,0 +1,30 @@
+package werror
+
+import (
+	h "net/http"
+	"strconv"
+)
+
+// MetricLabels returns a small, low-cardinality set of labels describing the Err, suitable for metrics:
+// "code" is the namespaced error code, "status_class" is the HTTP status class (e.g. "4xx" or "5xx"),
+// "retryable" reports whether retrying the request may succeed, and "severity" is the severity (see GetSeverity).
+// High-cardinality fields like Message or Metadata are deliberately excluded.
+func (e *Serr) MetricLabels() map[string]string {
+	return map[string]string{
+		"code":         string(e.Code),
+		"status_class": strconv.Itoa(e.HttpStatus/100) + "xx",
+		"retryable":    strconv.FormatBool(isRetryableStatus(e.HttpStatus)),
+		"severity":     e.GetSeverity().String(),
+	}
+}
+
+// isRetryableStatus reports whether a request which failed with the given HTTP status may succeed if retried.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case h.StatusRequestTimeout, h.StatusTooManyRequests,
+		h.StatusBadGateway, h.StatusServiceUnavailable, h.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+// Package metrics exposes werror errors as Prometheus metrics.
+package metrics
+
+import (
+	"strconv"
+
+	g "github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// Register registers a counter of created werror errors labeled by code and status with reg,
+// and sets werror.OnError to increment it.
+func Register(reg prometheus.Registerer) (*prometheus.CounterVec, error) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "werror_errors_total",
+		Help: "Total number of errors created, by error code and HTTP status.",
+	}, []string{"code", "status"})
+	if err := reg.Register(counter); err != nil {
+		return nil, err
+	}
+
+	werror.OnError = func(code werror.ErrCode, status int) {
+		counter.WithLabelValues(string(code), strconv.Itoa(status)).Inc()
+	}
+	return counter, nil
+}
+
+// ErrMetrics counts the errors returned by request handlers by code, HTTP status and severity.
+type ErrMetrics struct {
+	counter *prometheus.CounterVec
+}
+
+// NewErrMetrics creates an ErrMetrics and registers its web_errors_total counter with reg.
+func NewErrMetrics(reg prometheus.Registerer) (*ErrMetrics, error) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "web_errors_total",
+		Help: "Total number of errors returned by request handlers, by error code, HTTP status and severity.",
+	}, []string{"code", "http_status", "severity"})
+	if err := reg.Register(counter); err != nil {
+		return nil, err
+	}
+	return &ErrMetrics{counter: counter}, nil
+}
+
+// Observe increments the counter for werr's namespaced code, HTTP status and severity (see werror.SeverityOf).
+// A nil werr is ignored.
+func (m *ErrMetrics) Observe(werr werror.Err) {
+	if werr == nil {
+		return
+	}
+	m.counter.WithLabelValues(
+		string(werror.NamespacedCode(werr)),
+		strconv.Itoa(werr.GetHttpStatus()),
+		werror.SeverityOf(werr).String(),
+	).Inc()
+}
+
+// Middleware returns a Gin middleware that observes every error added with c.Error in the handler chain,
+// converted with werror.ToErr.
+func (m *ErrMetrics) Middleware() g.HandlerFunc {
+	return func(c *g.Context) {
+		c.Next()
+
+		for _, err := range c.Errors {
+			m.Observe(werror.ToErr(err.Err))
+		}
+	}
+}
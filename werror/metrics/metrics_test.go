@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	g "github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func TestRegister(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter, err := Register(reg)
+	if err != nil {
+		t.Fatalf("Register() failed: %v", err)
+	}
+	t.Cleanup(func() { werror.OnError = nil })
+
+	_ = werror.NewErr(werror.ErrNotFound, "User not found", "")
+	_ = werror.NewErr(werror.ErrNotFound, "Order not found", "")
+	_ = werror.NewErrFromError(werror.ErrConflict, errors.New("duplicate key"))
+
+	if got := testutil.ToFloat64(counter.WithLabelValues("NotFound", "404")); got != 2 {
+		t.Errorf("NotFound count = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(counter.WithLabelValues("Conflict", "409")); got != 1 {
+		t.Errorf("Conflict count = %v, want 1", got)
+	}
+
+	if _, err := Register(reg); err == nil {
+		t.Error("Register() twice should fail")
+	}
+}
+
+func TestErrMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewErrMetrics(reg)
+	if err != nil {
+		t.Fatalf("NewErrMetrics() failed: %v", err)
+	}
+
+	g.SetMode(g.TestMode)
+	r := g.New()
+	r.Use(m.Middleware())
+	r.GET("/user", func(c *g.Context) { _ = c.Error(werror.ErrNotFound) })
+	r.GET("/order", func(c *g.Context) { _ = c.Error(errors.New("database connection failed")) })
+	r.GET("/ok", func(c *g.Context) { c.Status(http.StatusOK) })
+	for _, path := range []string{"/user", "/user", "/order", "/ok"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	m.Observe(nil)
+
+	tests := []struct {
+		code     string
+		status   string
+		severity string
+		want     float64
+	}{
+		{"NotFound", "404", "warning", 2},
+		{"InternalServerError", "500", "error", 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(m.counter.WithLabelValues(tt.code, tt.status, tt.severity)); got != tt.want {
+			t.Errorf("web_errors_total{code=%q,http_status=%q,severity=%q} = %v, want %v",
+				tt.code, tt.status, tt.severity, got, tt.want)
+		}
+	}
+	if got := testutil.CollectAndCount(m.counter, "web_errors_total"); got != len(tests) {
+		t.Errorf("series count = %v, want %v", got, len(tests))
+	}
+
+	if _, err := NewErrMetrics(reg); err == nil {
+		t.Error("NewErrMetrics() twice should fail")
+	}
+}
@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestSerr_MetricLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Serr
+		want map[string]string
+	}{
+		{
+			"Bad request",
+			ErrBadRequest.(*Serr),
+			map[string]string{
+				"code": string(CodeBadRequest), "status_class": "4xx", "retryable": "false",
+				"severity": "warning",
+			},
+		},
+		{
+			"Throttle",
+			NewThrottleErr(1, 2, 5).Serr,
+			map[string]string{
+				"code": string(CodeTooManyRequests), "status_class": "4xx", "retryable": "true",
+				"severity": "warning",
+			},
+		},
+		{
+			"Internal server error",
+			ErrInternalServerError.(*Serr),
+			map[string]string{
+				"code": string(CodeInternalServerError), "status_class": "5xx", "retryable": "false",
+				"severity": "error",
+			},
+		},
+		{
+			"Service unavailable",
+			ErrServiceUnavailable.(*Serr),
+			map[string]string{
+				"code": string(CodeServiceUnavailable), "status_class": "5xx", "retryable": "true",
+				"severity": "error",
+			},
+		},
+		{
+			"Namespaced with params",
+			NewErrBuilder(ErrNotFound).WithNamespace("user").WithParam("id", "42").Build().(*Serr),
+			map[string]string{
+				"code": "user." + string(CodeNotFound), "status_class": "4xx", "retryable": "false",
+				"severity": "warning",
+			},
+		},
+		{
+			"Severity override",
+			ErrNotFound.(*Serr).WithSeverity(SeverityInfo),
+			map[string]string{
+				"code": string(CodeNotFound), "status_class": "4xx", "retryable": "false",
+				"severity": "info",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.MetricLabels(); !maps.Equal(got, tt.want) {
+				t.Errorf("MetricLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package werror
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// NewErrWithTemplate creates an Err from a base Err with code and the message rendered from the Go template tmpl
+// against params, e.g. "User {{.userId}} not found", like I18nErrTmpl.Render does for services not using go-i18n.
+// params are merged over the params of base as Metadata, see NewErrWithParams. If code is empty the base code is
+// used; either way the Err keeps the namespace of base. If tmpl fails to parse or render, e.g. for a key missing
+// from params while SetStrictTemplates is enabled, tmpl itself is used as message.
+func NewErrWithTemplate(base Err, code ErrCode, tmpl string, params map[string]any) Err {
+	if strings.TrimSpace(string(code)) == "" {
+		code = base.GetCode()
+	}
+	msg := renderMsgTmpl(string(code), tmpl, params)
+	if strings.TrimSpace(msg) == "" {
+		msg = base.GetMessage()
+	}
+	ns := namespaceOf(base)
+	return observe(&Serr{
+		error:      fmt.Errorf("%w: %s %s", base, code, msg),
+		HttpStatus: base.GetHttpStatus(),
+		Code:       namespacedCode(ns, code),
+		Namespace:  ns,
+		Message:    msg,
+		Metadata:   mergedParams(base, params),
+	})
+}
+
+// renderMsgTmpl renders tmpl against params, or returns tmpl if it fails to parse or render.
+func renderMsgTmpl(name, tmpl string, params map[string]any) string {
+	t, err := template.New(name).Funcs(localeFuncs(language.Und)).Parse(tmpl)
+	if err != nil {
+		return tmpl
+	}
+	if strictTemplates.Load() {
+		t.Option("missingkey=error")
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, params); err != nil {
+		return tmpl
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package werror
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestNewErrWithTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     ErrCode
+		tmpl     string
+		params   map[string]any
+		strict   bool
+		wantCode ErrCode
+		wantMsg  string
+	}{
+		{
+			name:     "Rendered",
+			code:     "UserNotFound",
+			tmpl:     "User {{.userId}} not found",
+			params:   map[string]any{"userId": 42},
+			wantCode: "UserNotFound",
+			wantMsg:  "User 42 not found",
+		},
+		{
+			name:     "Base code",
+			tmpl:     "{{.count}} items",
+			params:   map[string]any{"count": 3},
+			wantCode: CodeNotFound,
+			wantMsg:  "3 items",
+		},
+		{
+			name:     "Parse error falls back to the template",
+			code:     "UserNotFound",
+			tmpl:     "User {{.userId not found",
+			params:   map[string]any{"userId": 42},
+			wantCode: "UserNotFound",
+			wantMsg:  "User {{.userId not found",
+		},
+		{
+			name:     "Missing key",
+			code:     "UserNotFound",
+			tmpl:     "User {{.userId}} not found",
+			wantCode: "UserNotFound",
+			wantMsg:  "User <no value> not found",
+		},
+		{
+			name:     "Missing key with strict templates falls back to the template",
+			code:     "UserNotFound",
+			tmpl:     "User {{.userId}} not found",
+			params:   map[string]any{},
+			strict:   true,
+			wantCode: "UserNotFound",
+			wantMsg:  "User {{.userId}} not found",
+		},
+		{
+			name:     "Empty template uses the base message",
+			tmpl:     "",
+			wantCode: CodeNotFound,
+			wantMsg:  "Not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStrictTemplates(tt.strict)
+			t.Cleanup(func() { SetStrictTemplates(false) })
+
+			err := NewErrWithTemplate(ErrNotFound, tt.code, tt.tmpl, tt.params)
+
+			if err.GetCode() != tt.wantCode || err.GetMessage() != tt.wantMsg {
+				t.Errorf("NewErrWithTemplate() = %v %q, want %v %q",
+					err.GetCode(), err.GetMessage(), tt.wantCode, tt.wantMsg)
+			}
+			if err.GetHttpStatus() != http.StatusNotFound {
+				t.Errorf("GetHttpStatus() = %v, want %v", err.GetHttpStatus(), http.StatusNotFound)
+			}
+			if !slices.Contains(UnwrapChain(err), error(ErrNotFound)) {
+				t.Error("UnwrapChain(err) should contain the base Err")
+			}
+			if meta, _ := err.GetMetadata().(map[string]any); len(meta) != len(tt.params) {
+				t.Errorf("GetMetadata() = %v, want %v", err.GetMetadata(), tt.params)
+			}
+		})
+	}
+}
+
+func TestNewErrWithTemplate_Namespaced(t *testing.T) {
+	base := NewErrBuilder(ErrNotFound).WithNamespace("billing").Build()
+
+	err := NewErrWithTemplate(base, "LineNotFound", "Line {{.line}} not found", map[string]any{"line": 3})
+
+	if err.GetCode() != "LineNotFound" || NamespacedCode(err) != "billing.LineNotFound" {
+		t.Errorf("NewErrWithTemplate() code = %v (%v), want LineNotFound (billing.LineNotFound)",
+			err.GetCode(), NamespacedCode(err))
+	}
+}
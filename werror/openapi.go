@@ -0,0 +1,75 @@
+package werror
+
+import (
+	h "net/http"
+	"strconv"
+)
+
+// OpenAPIErrorSchemaName is the name under which OpenAPIErrorSchema must be added to the components.schemas
+// section of an OpenAPI document, as it is referenced by itself and OpenAPIResponses.
+const OpenAPIErrorSchemaName = "Error"
+
+const openAPIErrorSchemaRef = "#/components/schemas/" + OpenAPIErrorSchemaName
+
+// OpenAPIResponse is an OpenAPI 3 response object.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType is an OpenAPI 3 media type object.
+type OpenAPIMediaType struct {
+	Schema map[string]any `json:"schema"`
+}
+
+// OpenAPIErrorSchema returns the OpenAPI 3 schema of the JSON error envelope written by WriteError,
+// to be added as OpenAPIErrorSchemaName to components.schemas.
+// Errs with extra fields, e.g. ThrottleErr, serialize them as additional properties.
+func OpenAPIErrorSchema() map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"code", "message"},
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "string", "description": "Error code"},
+			"message": map[string]any{"type": "string", "description": "Error message"},
+			"subErrors": map[string]any{
+				"type":        "array",
+				"description": "Sub-errors that led to this error",
+				"items":       map[string]any{"$ref": openAPIErrorSchemaRef},
+			},
+			"metadata": map[string]any{"description": "Error metadata"},
+		},
+	}
+}
+
+// OpenAPIResponses returns an OpenAPI 3 response per HTTP status of the Catalog, keyed by the status, e.g. "404",
+// for the components.responses section, which operations can reference, e.g. "#/components/responses/404".
+// Each response enumerates the codes of the base Errs with its status.
+func OpenAPIResponses() map[string]OpenAPIResponse {
+	codes := map[int][]string{}
+	for _, spec := range Catalog() {
+		codes[spec.HttpStatus] = append(codes[spec.HttpStatus], string(spec.Code))
+	}
+
+	responses := make(map[string]OpenAPIResponse, len(codes))
+	for status, statusCodes := range codes {
+		description := h.StatusText(status)
+		if description == "" {
+			description = "Error " + strconv.Itoa(status)
+		}
+		responses[strconv.Itoa(status)] = OpenAPIResponse{
+			Description: description,
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: map[string]any{
+					"allOf": []any{
+						map[string]any{"$ref": openAPIErrorSchemaRef},
+						map[string]any{"properties": map[string]any{
+							"code": map[string]any{"type": "string", "enum": statusCodes},
+						}},
+					},
+				}},
+			},
+		}
+	}
+	return responses
+}
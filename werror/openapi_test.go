@@ -0,0 +1,85 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestOpenAPIResponses(t *testing.T) {
+	responses := OpenAPIResponses()
+
+	notFound, ok := responses["404"]
+	if !ok {
+		t.Fatal(`OpenAPIResponses() should have a "404" response`)
+	}
+	if notFound.Description != "Not Found" {
+		t.Errorf("Description = %q, want %q", notFound.Description, "Not Found")
+	}
+	for _, code := range []ErrCode{CodeNotFound, CodeResourceNotFound, CodeEndpointNotFound} {
+		if !slices.Contains(enumCodes(t, notFound), string(code)) {
+			t.Errorf(`"404" codes should contain %v`, code)
+		}
+	}
+	if _, ok := responses["499"]; !ok || responses["499"].Description != "Error 499" {
+		t.Errorf(`"499" response = %v, want a generic description`, responses["499"])
+	}
+}
+
+// TestOpenAPIErrorSchema checks that the schemas match the JSON actually written for Errs.
+func TestOpenAPIErrorSchema(t *testing.T) {
+	schema := OpenAPIErrorSchema()
+	//nolint:errcheck // type must match
+	properties := schema["properties"].(map[string]any)
+	responses := OpenAPIResponses()
+
+	withDetails := NewErrFromError(ErrBadRequest, errors.New("name is required"))
+	withDetails.SetMetadata(map[string]any{"field": "name"})
+	for _, err := range []Err{ErrNotFound, withDetails, ErrConflict} {
+		data, jerr := json.Marshal(err)
+		if jerr != nil {
+			t.Fatalf("json.Marshal() failed: %v", jerr)
+		}
+		var body map[string]any
+		if jerr := json.Unmarshal(data, &body); jerr != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", jerr)
+		}
+
+		for key := range body {
+			if _, ok := properties[key]; !ok {
+				t.Errorf("%s: property %q is not in the schema", data, key)
+			}
+		}
+		//nolint:errcheck // type must match
+		for _, key := range schema["required"].([]string) {
+			if _, ok := body[key]; !ok {
+				t.Errorf("%s: required property %q is missing", data, key)
+			}
+		}
+		response := responses[strconv.Itoa(err.GetHttpStatus())]
+		if code, _ := body["code"].(string); !slices.Contains(enumCodes(t, response), code) {
+			t.Errorf("%s: code %q is not enumerated for status %d", data, code, err.GetHttpStatus())
+		}
+	}
+}
+
+func enumCodes(t *testing.T, response OpenAPIResponse) []string {
+	t.Helper()
+	data, err := json.Marshal(response.Content["application/json"].Schema["allOf"])
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	var allOf []struct {
+		Properties struct {
+			Code struct {
+				Enum []string `json:"enum"`
+			} `json:"code"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &allOf); err != nil || len(allOf) != 2 {
+		t.Fatalf("unexpected allOf %s: %v", data, err)
+	}
+	return allOf[1].Properties.Code.Enum
+}
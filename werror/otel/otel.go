@@ -0,0 +1,92 @@
+// Package otel records werror errors on OpenTelemetry spans.
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// Span attribute keys.
+const (
+	AttrErrorCode      = attribute.Key("error.code")
+	AttrHttpStatusCode = attribute.Key("http.status_code")
+	AttrI18nID         = attribute.Key("error.i18n_id")
+	// AttrErrorParamPrefix prefixes the keys of the error's Metadata map entries.
+	AttrErrorParamPrefix = "error.param."
+)
+
+// RecordError records err on the span in ctx and sets the span status to Error.
+// If err wraps a werror.Err, its code, HTTP status and Metadata map entries are added as span attributes,
+// as well as the i18n message ID for a werror.I18nErr.
+// It does nothing if err is nil or ctx has no recording span.
+func RecordError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	var werr werror.Err
+	if !errors.As(err, &werr) {
+		return
+	}
+	span.SetAttributes(Attributes(werr)...)
+}
+
+// SetSpanError records werr on span like RecordError, for callers holding the span rather than its context:
+// the span status is set to Error with werr's message, werr is recorded as an exception event,
+// and its attributes (see Attributes) are added. If werr is nil, the span status is set to Ok.
+func SetSpanError(span trace.Span, werr werror.Err) {
+	if werr == nil {
+		span.SetStatus(codes.Ok, "")
+		return
+	}
+
+	span.SetStatus(codes.Error, werr.GetMessage())
+	span.RecordError(werr)
+	span.SetAttributes(Attributes(werr)...)
+}
+
+// Attributes returns the span attributes describing werr.
+func Attributes(werr werror.Err) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttrErrorCode.String(string(werror.NamespacedCode(werr))),
+		AttrHttpStatusCode.Int(werr.GetHttpStatus()),
+	}
+	if ierr, ok := werr.(werror.I18nErr); ok && ierr.GetI18n() != nil {
+		attrs = append(attrs, AttrI18nID.String(ierr.GetI18n().ID))
+	}
+	if params, ok := werr.GetMetadata().(map[string]any); ok {
+		for k, v := range params {
+			attrs = append(attrs, paramAttribute(AttrErrorParamPrefix+k, v))
+		}
+	}
+	return attrs
+}
+
+func paramAttribute(key string, v any) attribute.KeyValue {
+	k := attribute.Key(key)
+	switch x := v.(type) {
+	case string:
+		return k.String(x)
+	case bool:
+		return k.Bool(x)
+	case int:
+		return k.Int(x)
+	case int64:
+		return k.Int64(x)
+	case float64:
+		return k.Float64(x)
+	default:
+		return k.String(fmt.Sprint(x))
+	}
+}
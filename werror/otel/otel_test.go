@@ -0,0 +1,149 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+func record(t *testing.T, err error) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	RecordError(ctx, err)
+	span.End()
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("len(spans) = %v, want 1", len(spans))
+	}
+	return spans[0]
+}
+
+func attrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestRecordError(t *testing.T) {
+	err := werror.NewErrBuilder(werror.ErrNotFound).WithParam("userId", 7).Build()
+	span := record(t, err)
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("status = %v, want %v", span.Status().Code, codes.Error)
+	}
+	if len(span.Events()) == 0 {
+		t.Error("RecordError() should add an exception event")
+	}
+
+	got := attrs(span)
+	if got[AttrErrorCode].AsString() != "NotFound" {
+		t.Errorf("%s = %v, want NotFound", AttrErrorCode, got[AttrErrorCode].AsString())
+	}
+	if got[AttrHttpStatusCode].AsInt64() != 404 {
+		t.Errorf("%s = %v, want 404", AttrHttpStatusCode, got[AttrHttpStatusCode].AsInt64())
+	}
+	if got["error.param.userId"].AsInt64() != 7 {
+		t.Errorf("error.param.userId = %v, want 7", got["error.param.userId"].AsInt64())
+	}
+}
+
+func TestRecordError_I18nErr(t *testing.T) {
+	err := werror.MustNewI18nErr(werror.ErrNotFound, &i18n.Message{ID: "UserNotFound", Other: "User not found"}, nil)
+	span := record(t, err)
+
+	if got := attrs(span)[AttrI18nID].AsString(); got != "UserNotFound" {
+		t.Errorf("%s = %v, want UserNotFound", AttrI18nID, got)
+	}
+}
+
+func TestRecordError_StandardError(t *testing.T) {
+	span := record(t, errors.New("std error"))
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("status = %v, want %v", span.Status().Code, codes.Error)
+	}
+	if _, ok := attrs(span)[AttrErrorCode]; ok {
+		t.Errorf("%s should not be set for a standard error", AttrErrorCode)
+	}
+}
+
+func TestRecordError_Nil(t *testing.T) {
+	span := record(t, nil)
+
+	if span.Status().Code != codes.Unset {
+		t.Errorf("status = %v, want %v", span.Status().Code, codes.Unset)
+	}
+}
+
+// mockSpan records the calls made by SetSpanError.
+type mockSpan struct {
+	trace.Span
+
+	statusCode codes.Code
+	statusDesc string
+	errs       []error
+	attrs      []attribute.KeyValue
+}
+
+func (s *mockSpan) SetStatus(code codes.Code, description string) {
+	s.statusCode, s.statusDesc = code, description
+}
+
+func (s *mockSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *mockSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func TestSetSpanError(t *testing.T) {
+	err := werror.NewErr(werror.ErrConflict, "Order already paid", "")
+	span := &mockSpan{}
+	SetSpanError(span, err)
+
+	if span.statusCode != codes.Error || span.statusDesc != "Order already paid" {
+		t.Errorf("status = %v %q, want %v %q", span.statusCode, span.statusDesc, codes.Error, "Order already paid")
+	}
+	if len(span.errs) != 1 || !errors.Is(span.errs[0], werror.ErrConflict) {
+		t.Errorf("recorded errors = %v, want [%v]", span.errs, err)
+	}
+
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.attrs {
+		got[kv.Key] = kv.Value
+	}
+	if v, ok := got[AttrHttpStatusCode]; !ok || v.AsInt64() != 409 {
+		t.Errorf("%s = %v, want 409", AttrHttpStatusCode, v.AsInt64())
+	}
+	if v, ok := got[AttrErrorCode]; !ok || v.AsString() != "Conflict" {
+		t.Errorf("%s = %v, want Conflict", AttrErrorCode, v.AsString())
+	}
+}
+
+func TestSetSpanError_Nil(t *testing.T) {
+	span := &mockSpan{}
+	SetSpanError(span, nil)
+
+	if span.statusCode != codes.Ok || span.statusDesc != "" {
+		t.Errorf("status = %v %q, want %v", span.statusCode, span.statusDesc, codes.Ok)
+	}
+	if len(span.errs) != 0 || len(span.attrs) != 0 {
+		t.Errorf("SetSpanError(nil) recorded %v and %v, want nothing", span.errs, span.attrs)
+	}
+}
@@ -0,0 +1,67 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PaginationErr is an ErrPaginationOutOfRange telling the client which page it requested
+// and how many pages there are.
+type PaginationErr struct { //nolint:errname // lib
+	*Serr
+
+	TotalPages    int `json:"totalPages"    dc:"Total number of pages"`
+	RequestedPage int `json:"requestedPage" dc:"Requested page"`
+}
+
+// NewPaginationErr creates a PaginationErr for a requested page beyond the total number of pages.
+func NewPaginationErr(requested, total int) *PaginationErr {
+	msg := fmt.Sprintf("%s: page %d requested, but there are only %d pages",
+		ErrPaginationOutOfRange.GetMessage(), requested, total)
+	err := &PaginationErr{
+		Serr: &Serr{
+			error:      fmt.Errorf("%w: %s", ErrPaginationOutOfRange, msg),
+			HttpStatus: ErrPaginationOutOfRange.GetHttpStatus(),
+			Code:       ErrPaginationOutOfRange.GetCode(),
+			Message:    msg,
+		},
+		TotalPages:    total,
+		RequestedPage: requested,
+	}
+	observe(err)
+	return err
+}
+
+// GetSubErrors returns a synthetic sub-error with the requested page and total pages in its Metadata,
+// followed by the sub-errors set on the PaginationErr.
+func (e *PaginationErr) GetSubErrors() []Err {
+	boundary := NewBaseErr(e.HttpStatus, e.Code, fmt.Sprintf("page %d of %d", e.RequestedPage, e.TotalPages))
+	boundary.SetMetadata(map[string]any{"requestedPage": e.RequestedPage, "totalPages": e.TotalPages})
+	return append([]Err{boundary}, e.Serr.GetSubErrors()...)
+}
+
+// Clone returns a mutable copy of the PaginationErr.
+func (e *PaginationErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *PaginationErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	return &c
+}
+
+// MarshalJSON serializes the PaginationErr like Serr.MarshalJSON, with the page fields.
+func (e *PaginationErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *PaginationErr) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		TotalPages    int `json:"totalPages"`
+		RequestedPage int `json:"requestedPage"`
+	}{e.toJSON(ancestors), e.TotalPages, e.RequestedPage}
+}
@@ -0,0 +1,73 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPaginationErr_JSON(t *testing.T) {
+	data, err := json.Marshal(NewPaginationErr(7, 5))
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+
+	want := map[string]any{
+		"code":          "PaginationOutOfRange",
+		"message":       "The requested page is out of range: page 7 requested, but there are only 5 pages",
+		"totalPages":    float64(5),
+		"requestedPage": float64(7),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestPaginationErr_As(t *testing.T) {
+	err := fmt.Errorf("list orders: %w", NewPaginationErr(3, 2))
+
+	var perr *PaginationErr
+	if !errors.As(err, &perr) {
+		t.Fatal("errors.As() failed to extract *PaginationErr")
+	}
+	if perr.RequestedPage != 3 || perr.TotalPages != 2 {
+		t.Errorf("RequestedPage, TotalPages = %d, %d, want 3, 2", perr.RequestedPage, perr.TotalPages)
+	}
+	if !errors.Is(err, ErrPaginationOutOfRange) {
+		t.Error("errors.Is(err, ErrPaginationOutOfRange) = false, want true")
+	}
+	if got := perr.GetHttpStatus(); got != 400 {
+		t.Errorf("GetHttpStatus() = %d, want 400", got)
+	}
+}
+
+func TestPaginationErr_GetSubErrors(t *testing.T) {
+	subs := NewPaginationErr(3, 2).GetSubErrors()
+	if len(subs) != 1 {
+		t.Fatalf("GetSubErrors() returned %d sub-errors, want 1", len(subs))
+	}
+	meta, _ := subs[0].GetMetadata().(map[string]any)
+	if meta["requestedPage"] != 3 || meta["totalPages"] != 2 {
+		t.Errorf("sub-error Metadata = %v, want requestedPage 3 and totalPages 2", meta)
+	}
+}
+
+func TestPaginationErr_Clone(t *testing.T) {
+	orig := NewPaginationErr(3, 2)
+	c, ok := Clone(orig).(*PaginationErr)
+	if !ok {
+		t.Fatalf("Clone() returned %T, want *PaginationErr", Clone(orig))
+	}
+	c.SetMessage("changed")
+	if orig.GetMessage() == "changed" || c.TotalPages != 2 {
+		t.Errorf("Clone() should copy the fields and not share the Serr")
+	}
+}
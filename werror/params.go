@@ -0,0 +1,115 @@
+package werror
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+)
+
+// NewErrWithParams is like NewErr with params merged over the params of base as Metadata, params taking
+// precedence, so that params accumulate when an Err is wrapped repeatedly. Metadata of base is dropped if it is
+// not a map[string]any. Neither base nor params are modified.
+func NewErrWithParams(base Err, msg, msgDetail string, params map[string]any) Err {
+	werr := NewErr(base, msg, msgDetail)
+	if merged := mergedParams(base, params); merged != nil {
+		werr.SetMetadata(merged)
+	}
+	return werr
+}
+
+// MergeParams copies src into dst, overwriting the keys dst already has, and returns dst.
+// Like append, dst is allocated if it is nil and src is not empty.
+func MergeParams(dst, src map[string]any) map[string]any {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]any, len(src))
+	}
+	maps.Copy(dst, src)
+	return dst
+}
+
+// mergedParams returns a new map of the params of base's Metadata overridden by params, nil if both are empty.
+func mergedParams(base Err, params map[string]any) map[string]any {
+	baseParams, _ := base.GetMetadata().(map[string]any)
+	return MergeParams(MergeParams(nil, baseParams), params)
+}
+
+// param returns the value of key in the Err's Metadata if it is a map[string]any.
+func (e *Serr) param(key string) (any, bool) {
+	params, ok := e.Metadata.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	v, ok := params[key]
+	return v, ok
+}
+
+// ParamString returns the string value of key in the Err's Metadata.
+// It returns false if the Metadata is not a map[string]any, or the value is missing or not a string.
+func (e *Serr) ParamString(key string) (string, bool) {
+	v, _ := e.param(key)
+	s, ok := v.(string)
+	return s, ok
+}
+
+// ParamInt returns the integer value of key in the Err's Metadata.
+// Any integer type is accepted, as well as float64 and json.Number values holding an integer,
+// as produced by decoding JSON. It returns false for other values or if the value does not fit in int64.
+func (e *Serr) ParamInt(key string) (int64, bool) {
+	v, _ := e.param(key)
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return uintToInt64(uint64(n))
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return uintToInt64(n)
+	case float32:
+		return floatToInt64(float64(n))
+	case float64:
+		return floatToInt64(n)
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// ParamBool returns the bool value of key in the Err's Metadata.
+// It returns false if the Metadata is not a map[string]any, or the value is missing or not a bool.
+func (e *Serr) ParamBool(key string) (bool, bool) {
+	v, _ := e.param(key)
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func uintToInt64(n uint64) (int64, bool) {
+	if n > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(n), true
+}
+
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
@@ -0,0 +1,112 @@
+package werror
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSerr_Params(t *testing.T) {
+	// Metadata as decoded from JSON
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(`{"name":"alice","count":42,"ratio":1.5,"admin":true}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	err := NewErr(ErrBadRequest, "", "").(*Serr)
+	err.SetMetadata(decoded)
+
+	if s, ok := err.ParamString("name"); !ok || s != "alice" {
+		t.Errorf("ParamString(name) = %q, %v, want alice, true", s, ok)
+	}
+	if _, ok := err.ParamString("count"); ok {
+		t.Error("ParamString(count) should fail for a number")
+	}
+	if n, ok := err.ParamInt("count"); !ok || n != 42 {
+		t.Errorf("ParamInt(count) = %d, %v, want 42, true", n, ok)
+	}
+	if _, ok := err.ParamInt("ratio"); ok {
+		t.Error("ParamInt(ratio) should fail for a fraction")
+	}
+	if b, ok := err.ParamBool("admin"); !ok || !b {
+		t.Errorf("ParamBool(admin) = %v, %v, want true, true", b, ok)
+	}
+	if _, ok := err.ParamBool("missing"); ok {
+		t.Error("ParamBool(missing) should fail")
+	}
+}
+
+func TestSerr_ParamInt(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   int64
+		wantOK bool
+	}{
+		{"int", 7, 7, true},
+		{"int32", int32(-7), -7, true},
+		{"uint64", uint64(7), 7, true},
+		{"uint64 overflow", uint64(math.MaxUint64), 0, false},
+		{"float64", float64(1e6), 1000000, true},
+		{"float64 overflow", 1e19, 0, false},
+		{"json.Number", json.Number("123"), 123, true},
+		{"string", "7", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewErr(ErrBadRequest, "", "").(*Serr)
+			err.SetMetadata(map[string]any{"n": tt.value})
+			if got, ok := err.ParamInt("n"); got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParamInt() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := ErrBadRequest.(*Serr).ParamInt("n"); ok {
+		t.Error("ParamInt() should fail without Metadata")
+	}
+}
+
+func TestMergeParams(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  map[string]any
+		src  map[string]any
+		want map[string]any
+	}{
+		{"Both nil", nil, nil, nil},
+		{"Nil dst", nil, map[string]any{"a": 1}, map[string]any{"a": 1}},
+		{"Empty src", map[string]any{"a": 1}, nil, map[string]any{"a": 1}},
+		{
+			"src takes precedence",
+			map[string]any{"a": 1, "b": 2},
+			map[string]any{"b": 3},
+			map[string]any{"a": 1, "b": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeParams(tt.dst, tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewErrWithParams_WrapTwice(t *testing.T) {
+	base := NewErrWithParams(ErrNotFound, "", "", map[string]any{"userId": 7, "attempt": 1})
+	inner := NewErrWithParams(base, "", "user lookup", map[string]any{"attempt": 2, "orgId": 3})
+	//nolint:errcheck // type must match
+	outer := inner.(*Serr).Wrap("in handler")
+
+	want := map[string]any{"userId": 7, "attempt": 2, "orgId": 3}
+	if got := outer.GetMetadata(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMetadata() = %v, want %v", got, want)
+	}
+	if got, want := outer.GetMessage(), "Not found: user lookup: in handler"; got != want {
+		t.Errorf("GetMessage() = %q, want %q", got, want)
+	}
+	if got := base.GetMetadata(); !reflect.DeepEqual(got, map[string]any{"userId": 7, "attempt": 1}) {
+		t.Errorf("base Metadata modified to %v", got)
+	}
+}
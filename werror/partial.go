@@ -0,0 +1,22 @@
+package werror
+
+import (
+	"maps"
+	"slices"
+)
+
+// NewPartialSuccessErr creates an Err from ErrPartialSuccess (status 207) for a write of several records
+// where some failed. The succeeded IDs are recorded in Metadata as "succeeded", and each failure is converted
+// with ToErr and added as a sub-error with its ID in Metadata as "id", ordered by ID.
+func NewPartialSuccessErr(succeeded []string, failures map[string]error) Err {
+	err := NewErr(ErrPartialSuccess, "", "")
+	err.SetMetadata(map[string]any{"succeeded": slices.Clone(succeeded)})
+	for _, id := range slices.Sorted(maps.Keys(failures)) {
+		failure := ToErr(failures[id])
+		if failure == nil {
+			continue
+		}
+		err.AddSubErrors(NewErrBuilder(failure).WithParam("id", id).Build())
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewPartialSuccessErr(t *testing.T) {
+	// The succeeded IDs and the failures are written with the default verbosity, they are not internal
+	err := NewPartialSuccessErr([]string{"a", "c"}, map[string]error{
+		"d": NewErr(ErrConflict, "Order d already exists", ""),
+		"b": NewErr(ErrInvalidInput, "Order b is invalid", ""),
+		"e": nil,
+	})
+
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodPost, "/orders", nil), err)
+	if rec.Code != http.StatusMultiStatus {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMultiStatus)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	want := map[string]any{
+		"code":     "PartialSuccess",
+		"message":  "Some operations failed",
+		"metadata": map[string]any{"succeeded": []any{"a", "c"}},
+		"subErrors": []any{
+			map[string]any{
+				"code":     "InvalidInput",
+				"message":  "Order b is invalid",
+				"metadata": map[string]any{"id": "b"},
+			},
+			map[string]any{
+				"code":     "Conflict",
+				"message":  "Order d already exists",
+				"metadata": map[string]any{"id": "d"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+
+	if !errors.Is(err.GetSubErrors()[1], ErrConflict) {
+		t.Error("failures should keep their base Err")
+	}
+}
@@ -0,0 +1,25 @@
+package werror
+
+// WithPayload returns a copy of err carrying v, e.g. the conflicting entity, which can be retrieved
+// type-safely with PayloadOf, instead of putting structured data into Metadata. The payload is not serialized.
+// Errs that do not embed a Serr are wrapped in a new Err with the same status, code and message.
+func WithPayload[T any](err Err, v T) Err {
+	c, serr := cloneSerr(err)
+	serr.payload = v
+	return c
+}
+
+// PayloadOf returns the first payload of type T found in err's tree, see WithPayload.
+// It returns the zero value of T and false if there is none.
+func PayloadOf[T any](err error) (T, bool) {
+	var (
+		payload T
+		found   bool
+	)
+	walkErrTree(err, func(e error) {
+		if s, ok := e.(serrEmbedder); ok && !found {
+			payload, found = s.serr().payload.(T)
+		}
+	})
+	return payload, found
+}
@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type testOrder struct {
+	ID     int
+	Status string
+}
+
+func TestPayloadOf(t *testing.T) {
+	order := testOrder{ID: 42, Status: "shipped"}
+	conflict := WithPayload(NewErr(ErrConflict, "Order already shipped", ""), order)
+	wrapped := NewErrFromError(ErrInternalServerError, fmt.Errorf("cancel order: %w", conflict))
+
+	got, ok := PayloadOf[testOrder](wrapped)
+	if !ok || got != order {
+		t.Errorf("PayloadOf[testOrder]() = %v, %v, want %v, true", got, ok, order)
+	}
+	if got, ok := PayloadOf[*testOrder](wrapped); ok {
+		t.Errorf("PayloadOf[*testOrder]() = %v, true, want nil, false", got)
+	}
+	if _, ok := PayloadOf[testOrder](ErrConflict); ok {
+		t.Error("PayloadOf[testOrder](ErrConflict) should not find a payload, the base must not be modified")
+	}
+	if _, ok := PayloadOf[testOrder](nil); ok {
+		t.Error("PayloadOf[testOrder](nil) should not find a payload")
+	}
+}
+
+func TestWithPayload(t *testing.T) {
+	tmpl := MustNewI18nErrTmpl(ErrConflict, &i18n.Message{ID: "OrderConflict", Other: "Order conflict"})
+	ierr, err := tmpl.Render(nil)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		err  Err
+	}{
+		{"Serr sentinel", ErrConflict},
+		{"Si18nerr", ierr},
+		{"PaginationErr", NewPaginationErr(3, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			werr := WithPayload(tt.err, testOrder{ID: 1, Status: "secret"})
+			if werr.GetCode() != tt.err.GetCode() || werr.GetMessage() != tt.err.GetMessage() {
+				t.Errorf("WithPayload() = %v, want the code and message of %v", werr, tt.err)
+			}
+			if got, ok := PayloadOf[testOrder](werr); !ok || got.ID != 1 {
+				t.Errorf("PayloadOf[testOrder]() = %v, %v, want ID 1, true", got, ok)
+			}
+
+			data, err := json.Marshal(werr)
+			if err != nil {
+				t.Fatalf("json.Marshal() failed: %v", err)
+			}
+			if strings.Contains(string(data), "secret") || strings.Contains(string(data), "payload") {
+				t.Errorf("json.Marshal() = %s, the payload must not be serialized", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package werror
+
+import (
+	"strings"
+	"sync"
+)
+
+// ErrPool recycles Serrs to avoid allocating an Err on every call of high-frequency error paths.
+// An Err obtained from the pool must not be used, nor referenced e.g. as a sub-error, after it is put back.
+// The zero value is ready to use, and it is safe for concurrent use.
+type ErrPool struct {
+	pool sync.Pool
+}
+
+// pooledCause is the error wrapped by pooled Serrs, formatted like fmt.Errorf("%w: %s", base, msg)
+// but reused with its Serr instead of being allocated for every Err.
+type pooledCause struct {
+	base Err
+	msg  string
+}
+
+func (c *pooledCause) Error() string {
+	return c.base.Error() + ": " + c.msg
+}
+
+func (c *pooledCause) Unwrap() error {
+	return c.base
+}
+
+// Get returns a Serr from the pool derived from base like NewErr with msg, or base's message if msg is empty.
+func (p *ErrPool) Get(base Err, msg string) *Serr {
+	if msg == "" {
+		msg = base.GetMessage()
+	}
+	e, _ := p.pool.Get().(*Serr)
+	if e == nil {
+		e = &Serr{error: &pooledCause{}}
+	}
+	//nolint:errcheck // type must match
+	cause := e.error.(*pooledCause)
+	cause.base, cause.msg = base, msg
+
+	e.HttpStatus = base.GetHttpStatus()
+	e.Code = NamespacedCode(base)
+	e.Namespace = namespaceOf(base)
+	e.Message = msg
+	return e
+}
+
+// NewErr is a drop-in replacement for NewErr that gets the Err from the pool, see Get.
+func (p *ErrPool) NewErr(base Err, msg, msgDetail string) Err {
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		msg = base.GetMessage()
+	}
+	if msgDetail = strings.TrimSpace(msgDetail); msgDetail != "" {
+		msg = msg + ": " + msgDetail
+	}
+	return observe(p.Get(base, msg))
+}
+
+// Put resets e and puts it back into the pool. Serrs not obtained from an ErrPool are ignored.
+func (p *ErrPool) Put(e *Serr) {
+	if e == nil {
+		return
+	}
+	cause, ok := e.error.(*pooledCause)
+	if !ok {
+		return
+	}
+	*cause = pooledCause{}
+	*e = Serr{error: cause}
+	p.pool.Put(e)
+}
@@ -0,0 +1,80 @@
+package werror
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestErrPool(t *testing.T) {
+	var pool ErrPool
+
+	err := pool.Get(ErrNotFound, "User not found")
+	if err.GetCode() != CodeNotFound || err.GetHttpStatus() != 404 || err.GetMessage() != "User not found" {
+		t.Errorf("Get() = %v", err)
+	}
+	if want := NewErr(ErrNotFound, "User not found", "").Error(); err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false, want true")
+	}
+
+	err.AddSubErrors(ErrInvalidInput)
+	err.SetMetadata(map[string]any{"userId": 7})
+	pool.Put(err)
+	if err.GetSubErrors() != nil || err.GetMetadata() != nil || err.GetMessage() != "" {
+		t.Errorf("Put() did not reset the Err: %v", err)
+	}
+
+	reused := pool.Get(ErrConflict, "")
+	if reused.GetSubErrors() != nil || reused.GetMetadata() != nil || reused.GetMessage() != "Conflict" {
+		t.Errorf("Get() after Put() = %v, want a fresh Err", reused)
+	}
+
+	// Errs not from a pool are ignored
+	pool.Put(NewErr(ErrNotFound, "", "").(*Serr))
+	pool.Put(nil)
+}
+
+func TestErrPool_NewErr(t *testing.T) {
+	var pool ErrPool
+	tests := []struct {
+		msg       string
+		msgDetail string
+	}{
+		{"", ""},
+		{"User not found", ""},
+		{" User not found ", "id 7"},
+	}
+	for _, tt := range tests {
+		got, want := pool.NewErr(ErrNotFound, tt.msg, tt.msgDetail), NewErr(ErrNotFound, tt.msg, tt.msgDetail)
+		if !Equal(got, want) || got.Error() != want.Error() {
+			t.Errorf("NewErr(%q, %q) = %v, want %v", tt.msg, tt.msgDetail, got, want)
+		}
+	}
+}
+
+func benchmarkParallel(b *testing.B, fn func()) {
+	b.Helper()
+	b.ReportAllocs()
+	b.SetParallelism(max(1, 10000/runtime.GOMAXPROCS(0)))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			fn()
+		}
+	})
+}
+
+func BenchmarkNewErr(b *testing.B) {
+	benchmarkParallel(b, func() {
+		_ = NewErr(ErrNotFound, "User not found", "")
+	})
+}
+
+func BenchmarkErrPool(b *testing.B) {
+	var pool ErrPool
+	benchmarkParallel(b, func() {
+		pool.Put(pool.Get(ErrNotFound, "User not found"))
+	})
+}
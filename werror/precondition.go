@@ -0,0 +1,101 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPreconditionViolationsMissing is returned by PreconditionErr.Validate for a PreconditionErr without violations.
+var ErrPreconditionViolationsMissing = errors.New("PreconditionErr requires at least one violation")
+
+// PreconditionErr is an ErrPreconditionFailed listing the preconditions of the request which were violated,
+// e.g. "ETag mismatch".
+type PreconditionErr struct { //nolint:errname // lib
+	*Serr
+
+	Violations []string `json:"violations" dc:"Violated preconditions"`
+}
+
+// NewPreconditionErr creates a PreconditionErr with the given violations.
+// Without violations its message is the message of ErrPreconditionFailed, but Validate fails until one is added.
+func NewPreconditionErr(violations ...string) *PreconditionErr {
+	err := &PreconditionErr{
+		Serr: &Serr{
+			error:      fmt.Errorf("%w: %s", ErrPreconditionFailed, ErrPreconditionFailed.GetMessage()),
+			HttpStatus: ErrPreconditionFailed.GetHttpStatus(),
+			Code:       ErrPreconditionFailed.GetCode(),
+		},
+		Violations: violations,
+	}
+	err.updateMessage()
+	observe(err)
+	return err
+}
+
+// AddViolation adds a violated precondition to the PreconditionErr.
+func (e *PreconditionErr) AddViolation(violation string) {
+	if !e.mutable("AddViolation") {
+		return
+	}
+	e.Violations = append(e.Violations, violation)
+	e.updateMessage()
+}
+
+func (e *PreconditionErr) updateMessage() {
+	e.Message = ErrPreconditionFailed.GetMessage()
+	if len(e.Violations) > 0 {
+		e.Message += ": " + strings.Join(e.Violations, ", ")
+	}
+}
+
+// Validate returns ErrPreconditionViolationsMissing if the PreconditionErr has no violations.
+func (e *PreconditionErr) Validate() error {
+	if len(e.Violations) == 0 {
+		return ErrPreconditionViolationsMissing
+	}
+	return nil
+}
+
+// GetSubErrors returns the sub-errors of the PreconditionErr followed by an ErrPreconditionFailed for each violation,
+// so code that only knows about sub-errors still sees the violations. They are base Errs derived from
+// ErrPreconditionFailed, so reading them is not reported to OnError.
+func (e *PreconditionErr) GetSubErrors() []Err {
+	subs := e.Serr.GetSubErrors()
+	if len(e.Violations) == 0 {
+		return subs
+	}
+	errs := make([]Err, 0, len(subs)+len(e.Violations))
+	errs = append(errs, subs...)
+	for _, v := range e.Violations {
+		errs = append(errs, NewBaseErrFrom(ErrPreconditionFailed, "", v))
+	}
+	return errs
+}
+
+// Clone returns a mutable copy of the PreconditionErr.
+func (e *PreconditionErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *PreconditionErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	c.Violations = append([]string(nil), e.Violations...)
+	return &c
+}
+
+// MarshalJSON serializes the PreconditionErr like Serr.MarshalJSON, with the violations.
+func (e *PreconditionErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *PreconditionErr) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		Violations []string `json:"violations"`
+	}{e.toJSON(ancestors), e.Violations}
+}
@@ -0,0 +1,91 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewPreconditionErr(t *testing.T) {
+	tests := []struct {
+		name        string
+		violations  []string
+		wantMessage string
+		wantValid   bool
+	}{
+		{"no violations", nil, "Precondition failed", false},
+		{"one violation", []string{"ETag mismatch"}, "Precondition failed: ETag mismatch", true},
+		{
+			"two violations", []string{"ETag mismatch", "If-None-Match"},
+			"Precondition failed: ETag mismatch, If-None-Match", true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewPreconditionErr(tt.violations...)
+
+			if got := err.GetMessage(); got != tt.wantMessage {
+				t.Errorf("GetMessage() = %q, want %q", got, tt.wantMessage)
+			}
+			if verr := err.Validate(); (verr == nil) != tt.wantValid {
+				t.Errorf("Validate() = %v, want valid %v", verr, tt.wantValid)
+			}
+			if !tt.wantValid && !errors.Is(err.Validate(), ErrPreconditionViolationsMissing) {
+				t.Error("Validate() should return ErrPreconditionViolationsMissing")
+			}
+			if !errors.Is(err, ErrPreconditionFailed) {
+				t.Error("errors.Is(err, ErrPreconditionFailed) = false, want true")
+			}
+			if len(err.GetSubErrors()) != len(tt.violations) {
+				t.Fatalf("len(GetSubErrors()) = %d, want %d", len(err.GetSubErrors()), len(tt.violations))
+			}
+			for i, sub := range err.GetSubErrors() {
+				if sub.GetCode() != CodePreconditionFailed || sub.GetMessage() != tt.violations[i] {
+					t.Errorf("GetSubErrors()[%d] = %v, want %q", i, sub, tt.violations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPreconditionErr_AddViolation(t *testing.T) {
+	err := NewPreconditionErr()
+	err.AddViolation("ETag mismatch")
+	err.AddViolation("If-None-Match")
+
+	if verr := err.Validate(); verr != nil {
+		t.Errorf("Validate() = %v, want nil", verr)
+	}
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	want := `{"code":"PreconditionFailed","message":"Precondition failed: ETag mismatch, If-None-Match",` +
+		`"violations":["ETag mismatch","If-None-Match"]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	clone, _ := Clone(err).(*PreconditionErr)
+	clone.AddViolation("If-Match")
+	if len(err.Violations) != 2 {
+		t.Error("AddViolation() on a clone should not modify the original")
+	}
+	if perr, ok := As[*PreconditionErr](err); !ok || perr != err {
+		t.Error("As[*PreconditionErr]() should find the PreconditionErr")
+	}
+}
+
+func TestPreconditionErr_GetSubErrors_OnError(t *testing.T) {
+	var calls int
+	OnError = func(ErrCode, int) { calls++ }
+	t.Cleanup(func() { OnError = nil })
+
+	err := NewPreconditionErr("ETag mismatch", "If-None-Match")
+	_ = err.GetSubErrors()
+	_ = err.GetSubErrors()
+
+	if calls != 1 {
+		t.Errorf("OnError calls = %d, want 1 for NewPreconditionErr only", calls)
+	}
+}
@@ -0,0 +1,208 @@
+// Code generated by genpredicates from error.go; DO NOT EDIT.
+
+package werror
+
+// IsPartialSuccess reports whether err wraps an Err with code CodePartialSuccess.
+func IsPartialSuccess(err error) bool {
+	return IsErrOf(err, CodePartialSuccess)
+}
+
+// IsBadRequest reports whether err wraps an Err with code CodeBadRequest.
+func IsBadRequest(err error) bool {
+	return IsErrOf(err, CodeBadRequest)
+}
+
+// IsBadArgument reports whether err wraps an Err with code CodeBadArgument.
+func IsBadArgument(err error) bool {
+	return IsErrOf(err, CodeBadArgument)
+}
+
+// IsInvalidInput reports whether err wraps an Err with code CodeInvalidInput.
+func IsInvalidInput(err error) bool {
+	return IsErrOf(err, CodeInvalidInput)
+}
+
+// IsInvalidOperation reports whether err wraps an Err with code CodeInvalidOperation.
+func IsInvalidOperation(err error) bool {
+	return IsErrOf(err, CodeInvalidOperation)
+}
+
+// IsPasswordTooWeak reports whether err wraps an Err with code CodePasswordTooWeak.
+func IsPasswordTooWeak(err error) bool {
+	return IsErrOf(err, CodePasswordTooWeak)
+}
+
+// IsPaginationOutOfRange reports whether err wraps an Err with code CodePaginationOutOfRange.
+func IsPaginationOutOfRange(err error) bool {
+	return IsErrOf(err, CodePaginationOutOfRange)
+}
+
+// IsUnauthorized reports whether err wraps an Err with code CodeUnauthorized.
+func IsUnauthorized(err error) bool {
+	return IsErrOf(err, CodeUnauthorized)
+}
+
+// IsInvalidLoginCredential reports whether err wraps an Err with code CodeInvalidLoginCredential.
+func IsInvalidLoginCredential(err error) bool {
+	return IsErrOf(err, CodeInvalidLoginCredential)
+}
+
+// IsAlreadyLoggedIn reports whether err wraps an Err with code CodeAlreadyLoggedIn.
+func IsAlreadyLoggedIn(err error) bool {
+	return IsErrOf(err, CodeAlreadyLoggedIn)
+}
+
+// IsInvalidAuthenticationInfo reports whether err wraps an Err with code CodeInvalidAuthenticationInfo.
+func IsInvalidAuthenticationInfo(err error) bool {
+	return IsErrOf(err, CodeInvalidAuthenticationInfo)
+}
+
+// IsForbidden reports whether err wraps an Err with code CodeForbidden.
+func IsForbidden(err error) bool {
+	return IsErrOf(err, CodeForbidden)
+}
+
+// IsAuthenticationFailed reports whether err wraps an Err with code CodeAuthenticationFailed.
+func IsAuthenticationFailed(err error) bool {
+	return IsErrOf(err, CodeAuthenticationFailed)
+}
+
+// IsInsufficientAccountPermissions reports whether err wraps an Err with code CodeInsufficientAccountPermissions.
+func IsInsufficientAccountPermissions(err error) bool {
+	return IsErrOf(err, CodeInsufficientAccountPermissions)
+}
+
+// IsNotFound reports whether err wraps an Err with code CodeNotFound.
+func IsNotFound(err error) bool {
+	return IsErrOf(err, CodeNotFound)
+}
+
+// IsEndpointNotFound reports whether err wraps an Err with code CodeEndpointNotFound.
+func IsEndpointNotFound(err error) bool {
+	return IsErrOf(err, CodeEndpointNotFound)
+}
+
+// IsResourceNotFound reports whether err wraps an Err with code CodeResourceNotFound.
+func IsResourceNotFound(err error) bool {
+	return IsErrOf(err, CodeResourceNotFound)
+}
+
+// IsMethodNotAllowed reports whether err wraps an Err with code CodeMethodNotAllowed.
+func IsMethodNotAllowed(err error) bool {
+	return IsErrOf(err, CodeMethodNotAllowed)
+}
+
+// IsNotSupported reports whether err wraps an Err with code CodeNotSupported.
+func IsNotSupported(err error) bool {
+	return IsErrOf(err, CodeNotSupported)
+}
+
+// IsTimeout reports whether err wraps an Err with code CodeTimeout.
+func IsTimeout(err error) bool {
+	return IsErrOf(err, CodeTimeout)
+}
+
+// IsRequestTimeout reports whether err wraps an Err with code CodeRequestTimeout.
+func IsRequestTimeout(err error) bool {
+	return IsErrOf(err, CodeRequestTimeout)
+}
+
+// IsDeadlineExceeded reports whether err wraps an Err with code CodeDeadlineExceeded.
+func IsDeadlineExceeded(err error) bool {
+	return IsErrOf(err, CodeDeadlineExceeded)
+}
+
+// IsConflict reports whether err wraps an Err with code CodeConflict.
+func IsConflict(err error) bool {
+	return IsErrOf(err, CodeConflict)
+}
+
+// IsResourceAlreadyExists reports whether err wraps an Err with code CodeResourceAlreadyExists.
+func IsResourceAlreadyExists(err error) bool {
+	return IsErrOf(err, CodeResourceAlreadyExists)
+}
+
+// IsAccountAlreadyExists reports whether err wraps an Err with code CodeAccountAlreadyExists.
+func IsAccountAlreadyExists(err error) bool {
+	return IsErrOf(err, CodeAccountAlreadyExists)
+}
+
+// IsIdempotencyKeyConflict reports whether err wraps an Err with code CodeIdempotencyKeyConflict.
+func IsIdempotencyKeyConflict(err error) bool {
+	return IsErrOf(err, CodeIdempotencyKeyConflict)
+}
+
+// IsGone reports whether err wraps an Err with code CodeGone.
+func IsGone(err error) bool {
+	return IsErrOf(err, CodeGone)
+}
+
+// IsPreconditionFailed reports whether err wraps an Err with code CodePreconditionFailed.
+func IsPreconditionFailed(err error) bool {
+	return IsErrOf(err, CodePreconditionFailed)
+}
+
+// IsPayloadTooLarge reports whether err wraps an Err with code CodePayloadTooLarge.
+func IsPayloadTooLarge(err error) bool {
+	return IsErrOf(err, CodePayloadTooLarge)
+}
+
+// IsRequestEntityTooLarge reports whether err wraps an Err with code CodeRequestEntityTooLarge.
+func IsRequestEntityTooLarge(err error) bool {
+	return IsErrOf(err, CodeRequestEntityTooLarge)
+}
+
+// IsUnprocessableEntity reports whether err wraps an Err with code CodeUnprocessableEntity.
+func IsUnprocessableEntity(err error) bool {
+	return IsErrOf(err, CodeUnprocessableEntity)
+}
+
+// IsLocked reports whether err wraps an Err with code CodeLocked.
+func IsLocked(err error) bool {
+	return IsErrOf(err, CodeLocked)
+}
+
+// IsFailedDependency reports whether err wraps an Err with code CodeFailedDependency.
+func IsFailedDependency(err error) bool {
+	return IsErrOf(err, CodeFailedDependency)
+}
+
+// IsTooManyRequests reports whether err wraps an Err with code CodeTooManyRequests.
+func IsTooManyRequests(err error) bool {
+	return IsErrOf(err, CodeTooManyRequests)
+}
+
+// IsClientClosedRequest reports whether err wraps an Err with code CodeClientClosedRequest.
+func IsClientClosedRequest(err error) bool {
+	return IsErrOf(err, CodeClientClosedRequest)
+}
+
+// IsRequestCanceled reports whether err wraps an Err with code CodeRequestCanceled.
+func IsRequestCanceled(err error) bool {
+	return IsErrOf(err, CodeRequestCanceled)
+}
+
+// IsInternalError reports whether err wraps an Err with code CodeInternalError.
+func IsInternalError(err error) bool {
+	return IsErrOf(err, CodeInternalError)
+}
+
+// IsInternalServerError reports whether err wraps an Err with code CodeInternalServerError.
+func IsInternalServerError(err error) bool {
+	return IsErrOf(err, CodeInternalServerError)
+}
+
+// IsNotImplemented reports whether err wraps an Err with code CodeNotImplemented.
+func IsNotImplemented(err error) bool {
+	return IsErrOf(err, CodeNotImplemented)
+}
+
+// IsServiceUnavailable reports whether err wraps an Err with code CodeServiceUnavailable.
+func IsServiceUnavailable(err error) bool {
+	return IsErrOf(err, CodeServiceUnavailable)
+}
+
+// IsServerBusy reports whether err wraps an Err with code CodeServerBusy.
+func IsServerBusy(err error) bool {
+	return IsErrOf(err, CodeServerBusy)
+}
@@ -0,0 +1,30 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	tests := []struct {
+		name string
+		pred func(error) bool
+		err  error
+		want bool
+	}{
+		{"IsNotFound matches sentinel", IsNotFound, ErrNotFound, true},
+		{"IsNotFound matches wrapped", IsNotFound, fmt.Errorf("lookup: %w", NewErr(ErrNotFound, "user", "")), true},
+		{"IsNotFound rejects other code", IsNotFound, ErrConflict, false},
+		{"IsUnauthorized matches joined", IsUnauthorized, errors.Join(errors.New("std"), ErrUnauthorized), true},
+		{"IsConflict rejects std error", IsConflict, errors.New("conflict"), false},
+		{"IsConflict rejects nil", IsConflict, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
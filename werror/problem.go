@@ -0,0 +1,121 @@
+package werror
+
+import (
+	"encoding/json"
+	"maps"
+	h "net/http"
+)
+
+// ProblemDetails is a problem details document as defined by RFC 9457.
+// Reference: https://www.rfc-editor.org/rfc/rfc9457
+type ProblemDetails struct {
+	// A URI reference that identifies the problem type.
+	Type string `json:"type,omitempty"`
+	// A short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// The HTTP status code.
+	Status int `json:"status,omitempty"`
+	// A human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// A URI reference that identifies the specific occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Extension members, serialized as top-level members of the document.
+	Extensions map[string]any `json:"-"`
+}
+
+// ProblemPagination describes the page of sub-problems included in a problem details list.
+type ProblemPagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+// MarshalJSON serializes the problem details with Extensions inlined as top-level members.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := maps.Clone(p.Extensions)
+	if m == nil {
+		m = map[string]any{}
+	}
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// ToProblemDetails converts err to a problem details document.
+// The namespaced code (see NamespacedCode) is included as the "code" extension member.
+func ToProblemDetails(err Err) ProblemDetails {
+	return ProblemDetails{
+		Type:       "about:blank",
+		Title:      h.StatusText(err.GetHttpStatus()),
+		Status:     err.GetHttpStatus(),
+		Detail:     err.GetMessage(),
+		Extensions: map[string]any{"code": NamespacedCode(err)},
+	}
+}
+
+// ToProblemDetailsList converts errs to a problem details document whose "problems" extension member
+// holds the page of errs starting at offset with at most limit entries (all remaining if limit <= 0).
+// The "pagination" extension member describes the page.
+// The status is the one shared by all errs, otherwise 400 if they are all client errors, otherwise 500.
+// If errs is empty, the "problems" list is empty and the document has no status or title, as nothing failed.
+func ToProblemDetailsList(errs []Err, offset, limit int) ProblemDetails {
+	total := len(errs)
+	offset = min(max(offset, 0), total)
+	end := total
+	if limit > 0 {
+		end = min(offset+limit, total)
+	}
+
+	problems := make([]ProblemDetails, 0, end-offset)
+	for _, err := range errs[offset:end] {
+		problems = append(problems, ToProblemDetails(err))
+	}
+
+	status := problemListStatus(errs)
+	return ProblemDetails{
+		Type:   "about:blank",
+		Title:  h.StatusText(status),
+		Status: status,
+		Extensions: map[string]any{
+			"problems":   problems,
+			"pagination": ProblemPagination{Offset: offset, Limit: limit, Total: total},
+		},
+	}
+}
+
+func problemListStatus(errs []Err) int {
+	if len(errs) == 0 {
+		return 0
+	}
+	status, allClient := errs[0].GetHttpStatus(), true
+	for _, err := range errs {
+		s := err.GetHttpStatus()
+		if s != status {
+			status = 0
+		}
+		if s < 400 || s >= 500 {
+			allClient = false
+		}
+	}
+	switch {
+	case status != 0:
+		return status
+	case allClient:
+		return h.StatusBadRequest
+	default:
+		return h.StatusInternalServerError
+	}
+}
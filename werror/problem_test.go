@@ -0,0 +1,136 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestToProblemDetails(t *testing.T) {
+	pd := ToProblemDetails(ErrNotFound)
+
+	data, err := json.Marshal(pd)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+
+	want := map[string]any{
+		"type":   "about:blank",
+		"title":  "Not Found",
+		"status": float64(http.StatusNotFound),
+		"detail": "Not found",
+		"code":   "NotFound",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestToProblemDetails_Namespaced(t *testing.T) {
+	base := NewErrBuilder(ErrNotFound).WithNamespace("billing").Build()
+
+	if got := ToProblemDetails(base).Extensions["code"]; got != ErrCode("billing.NotFound") {
+		t.Errorf("code = %v, want billing.NotFound", got)
+	}
+}
+
+func TestToProblemDetailsList(t *testing.T) {
+	errs := make([]Err, 0, 25)
+	for i := range 25 {
+		errs = append(errs, NewErr(ErrInvalidInput, fmt.Sprintf("Item %d is invalid", i), ""))
+	}
+
+	tests := []struct {
+		name      string
+		offset    int
+		limit     int
+		wantLen   int
+		wantFirst string
+	}{
+		{name: "First page", offset: 0, limit: 10, wantLen: 10, wantFirst: "Item 0 is invalid"},
+		{name: "Last partial page", offset: 20, limit: 10, wantLen: 5, wantFirst: "Item 20 is invalid"},
+		{name: "Offset beyond total", offset: 30, limit: 10, wantLen: 0},
+		{name: "Negative offset", offset: -5, limit: 3, wantLen: 3, wantFirst: "Item 0 is invalid"},
+		{name: "No limit", offset: 5, limit: 0, wantLen: 20, wantFirst: "Item 5 is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := ToProblemDetailsList(errs, tt.offset, tt.limit)
+
+			if pd.Status != http.StatusBadRequest {
+				t.Errorf("Status = %v, want %v", pd.Status, http.StatusBadRequest)
+			}
+
+			problems, ok := pd.Extensions["problems"].([]ProblemDetails)
+			if !ok {
+				t.Fatalf("problems extension = %T, want []ProblemDetails", pd.Extensions["problems"])
+			}
+			if len(problems) != tt.wantLen {
+				t.Fatalf("len(problems) = %v, want %v", len(problems), tt.wantLen)
+			}
+			if tt.wantLen > 0 && problems[0].Detail != tt.wantFirst {
+				t.Errorf("problems[0].Detail = %v, want %v", problems[0].Detail, tt.wantFirst)
+			}
+
+			pagination, ok := pd.Extensions["pagination"].(ProblemPagination)
+			if !ok || pagination.Total != len(errs) {
+				t.Errorf("pagination = %v, want total %v", pd.Extensions["pagination"], len(errs))
+			}
+
+			if _, err := json.Marshal(pd); err != nil {
+				t.Errorf("json.Marshal() failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestToProblemDetailsList_Empty(t *testing.T) {
+	for _, errs := range [][]Err{nil, {}} {
+		pd := ToProblemDetailsList(errs, 0, 10)
+
+		if problems, _ := pd.Extensions["problems"].([]ProblemDetails); problems == nil || len(problems) != 0 {
+			t.Errorf("problems = %#v, want an empty list", pd.Extensions["problems"])
+		}
+		data, err := json.Marshal(pd)
+		if err != nil {
+			t.Fatalf("json.Marshal() failed: %v", err)
+		}
+		want := `{"pagination":{"offset":0,"limit":10,"total":0},"problems":[],"type":"about:blank"}`
+		if string(data) != want {
+			t.Errorf("json.Marshal() = %s, want %s", data, want)
+		}
+	}
+}
+
+func TestToProblemDetailsList_Status(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []Err
+		want int
+	}{
+		{name: "Shared status", errs: []Err{ErrNotFound, ErrResourceNotFound}, want: http.StatusNotFound},
+		{name: "Mixed client errors", errs: []Err{ErrNotFound, ErrConflict}, want: http.StatusBadRequest},
+		{
+			name: "Mixed with server error",
+			errs: []Err{ErrNotFound, ErrServerBusy},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToProblemDetailsList(tt.errs, 0, 0).Status; got != tt.want {
+				t.Errorf("Status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: werror.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Kind of a metadata value.
+type MetadataEntry_Kind int32
+
+const (
+	// Encoded as JSON, e.g. null, lists and objects.
+	MetadataEntry_KIND_JSON   MetadataEntry_Kind = 0
+	MetadataEntry_KIND_STRING MetadataEntry_Kind = 1
+	MetadataEntry_KIND_NUMBER MetadataEntry_Kind = 2
+	MetadataEntry_KIND_BOOL   MetadataEntry_Kind = 3
+)
+
+// Enum value maps for MetadataEntry_Kind.
+var (
+	MetadataEntry_Kind_name = map[int32]string{
+		0: "KIND_JSON",
+		1: "KIND_STRING",
+		2: "KIND_NUMBER",
+		3: "KIND_BOOL",
+	}
+	MetadataEntry_Kind_value = map[string]int32{
+		"KIND_JSON":   0,
+		"KIND_STRING": 1,
+		"KIND_NUMBER": 2,
+		"KIND_BOOL":   3,
+	}
+)
+
+func (x MetadataEntry_Kind) Enum() *MetadataEntry_Kind {
+	p := new(MetadataEntry_Kind)
+	*p = x
+	return p
+}
+
+func (x MetadataEntry_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MetadataEntry_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_werror_proto_enumTypes[0].Descriptor()
+}
+
+func (MetadataEntry_Kind) Type() protoreflect.EnumType {
+	return &file_werror_proto_enumTypes[0]
+}
+
+func (x MetadataEntry_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MetadataEntry_Kind.Descriptor instead.
+func (MetadataEntry_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_werror_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// WErrorProto is the binary encoding of a werror.Err.
+type WErrorProto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HTTP status code.
+	HttpStatus int32 `protobuf:"varint,1,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	// One of a server-defined set of error codes, prefixed with its namespace and a dot if namespaced.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// A human-readable representation of the error.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Specific errors that led to this error.
+	SubErrors []*WErrorProto `protobuf:"bytes,4,rep,name=sub_errors,json=subErrors,proto3" json:"sub_errors,omitempty"`
+	// Error metadata that is an object, one entry per key.
+	Metadata []*MetadataEntry `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	// Error metadata that is not an object encoded as JSON, empty if none.
+	MetadataJson  []byte `protobuf:"bytes,6,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WErrorProto) Reset() {
+	*x = WErrorProto{}
+	mi := &file_werror_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WErrorProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WErrorProto) ProtoMessage() {}
+
+func (x *WErrorProto) ProtoReflect() protoreflect.Message {
+	mi := &file_werror_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WErrorProto.ProtoReflect.Descriptor instead.
+func (*WErrorProto) Descriptor() ([]byte, []int) {
+	return file_werror_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WErrorProto) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+func (x *WErrorProto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *WErrorProto) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WErrorProto) GetSubErrors() []*WErrorProto {
+	if x != nil {
+		return x.SubErrors
+	}
+	return nil
+}
+
+func (x *WErrorProto) GetMetadata() []*MetadataEntry {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *WErrorProto) GetMetadataJson() []byte {
+	if x != nil {
+		return x.MetadataJson
+	}
+	return nil
+}
+
+// MetadataEntry is a key of error metadata with its value, in the field selected by kind.
+// It has no oneof, which would cost an allocation per entry when encoding and decoding.
+type MetadataEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Kind          MetadataEntry_Kind     `protobuf:"varint,2,opt,name=kind,proto3,enum=werror.MetadataEntry_Kind" json:"kind,omitempty"`
+	StringValue   string                 `protobuf:"bytes,3,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	NumberValue   float64                `protobuf:"fixed64,4,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
+	BoolValue     bool                   `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
+	JsonValue     []byte                 `protobuf:"bytes,6,opt,name=json_value,json=jsonValue,proto3" json:"json_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataEntry) Reset() {
+	*x = MetadataEntry{}
+	mi := &file_werror_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataEntry) ProtoMessage() {}
+
+func (x *MetadataEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_werror_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataEntry.ProtoReflect.Descriptor instead.
+func (*MetadataEntry) Descriptor() ([]byte, []int) {
+	return file_werror_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MetadataEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MetadataEntry) GetKind() MetadataEntry_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return MetadataEntry_KIND_JSON
+}
+
+func (x *MetadataEntry) GetStringValue() string {
+	if x != nil {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (x *MetadataEntry) GetNumberValue() float64 {
+	if x != nil {
+		return x.NumberValue
+	}
+	return 0
+}
+
+func (x *MetadataEntry) GetBoolValue() bool {
+	if x != nil {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (x *MetadataEntry) GetJsonValue() []byte {
+	if x != nil {
+		return x.JsonValue
+	}
+	return nil
+}
+
+var File_werror_proto protoreflect.FileDescriptor
+
+const file_werror_proto_rawDesc = "" +
+	"\n" +
+	"\fwerror.proto\x12\x06werror\"\xe8\x01\n" +
+	"\vWErrorProto\x12\x1f\n" +
+	"\vhttp_status\x18\x01 \x01(\x05R\n" +
+	"httpStatus\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x122\n" +
+	"\n" +
+	"sub_errors\x18\x04 \x03(\v2\x13.werror.WErrorProtoR\tsubErrors\x121\n" +
+	"\bmetadata\x18\x05 \x03(\v2\x15.werror.MetadataEntryR\bmetadata\x12#\n" +
+	"\rmetadata_json\x18\x06 \x01(\fR\fmetadataJson\"\x9d\x02\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1a.werror.MetadataEntry.KindR\x04kind\x12!\n" +
+	"\fstring_value\x18\x03 \x01(\tR\vstringValue\x12!\n" +
+	"\fnumber_value\x18\x04 \x01(\x01R\vnumberValue\x12\x1d\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bR\tboolValue\x12\x1d\n" +
+	"\n" +
+	"json_value\x18\x06 \x01(\fR\tjsonValue\"F\n" +
+	"\x04Kind\x12\r\n" +
+	"\tKIND_JSON\x10\x00\x12\x0f\n" +
+	"\vKIND_STRING\x10\x01\x12\x0f\n" +
+	"\vKIND_NUMBER\x10\x02\x12\r\n" +
+	"\tKIND_BOOL\x10\x03B3Z1github.com/daotl/go-web-common/werror/proto/pb;pbb\x06proto3"
+
+var (
+	file_werror_proto_rawDescOnce sync.Once
+	file_werror_proto_rawDescData []byte
+)
+
+func file_werror_proto_rawDescGZIP() []byte {
+	file_werror_proto_rawDescOnce.Do(func() {
+		file_werror_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_werror_proto_rawDesc), len(file_werror_proto_rawDesc)))
+	})
+	return file_werror_proto_rawDescData
+}
+
+var file_werror_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_werror_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_werror_proto_goTypes = []any{
+	(MetadataEntry_Kind)(0), // 0: werror.MetadataEntry.Kind
+	(*WErrorProto)(nil),     // 1: werror.WErrorProto
+	(*MetadataEntry)(nil),   // 2: werror.MetadataEntry
+}
+var file_werror_proto_depIdxs = []int32{
+	1, // 0: werror.WErrorProto.sub_errors:type_name -> werror.WErrorProto
+	2, // 1: werror.WErrorProto.metadata:type_name -> werror.MetadataEntry
+	0, // 2: werror.MetadataEntry.kind:type_name -> werror.MetadataEntry.Kind
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_werror_proto_init() }
+func file_werror_proto_init() {
+	if File_werror_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_werror_proto_rawDesc), len(file_werror_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_werror_proto_goTypes,
+		DependencyIndexes: file_werror_proto_depIdxs,
+		EnumInfos:         file_werror_proto_enumTypes,
+		MessageInfos:      file_werror_proto_msgTypes,
+	}.Build()
+	File_werror_proto = out.File
+	file_werror_proto_goTypes = nil
+	file_werror_proto_depIdxs = nil
+}
@@ -0,0 +1,116 @@
+// Package proto converts werror.Errs to and from protobuf messages,
+// a faster alternative to JSON for high-throughput internal services.
+package proto
+
+//go:generate protoc --go_out=pb --go_opt=paths=source_relative werror.proto
+
+import (
+	"encoding/json"
+
+	"github.com/daotl/go-web-common/werror"
+	"github.com/daotl/go-web-common/werror/proto/pb"
+)
+
+// ErrToProto converts err to a WErrorProto with its namespaced code (see werror.NamespacedCode),
+// sub-errors are converted recursively. Metadata that is a non-empty map[string]any is converted entry by entry,
+// strings, numbers and booleans natively and other values as JSON, other Metadata is encoded as JSON.
+// Metadata that cannot be encoded as JSON is dropped. It returns nil if err is nil.
+func ErrToProto(err werror.Err) *pb.WErrorProto {
+	if err == nil {
+		return nil
+	}
+
+	p := &pb.WErrorProto{
+		HttpStatus: int32(err.GetHttpStatus()), //nolint:gosec // HTTP statuses fit in int32
+		Code:       string(werror.NamespacedCode(err)),
+		Message:    err.GetMessage(),
+	}
+	if meta, ok := err.GetMetadata().(map[string]any); ok && len(meta) > 0 {
+		p.Metadata = metadataToProto(meta)
+	} else if meta := err.GetMetadata(); meta != nil {
+		if data, jerr := json.Marshal(meta); jerr == nil {
+			p.MetadataJson = data
+		}
+	}
+	if subs := err.GetSubErrors(); len(subs) > 0 {
+		p.SubErrors = make([]*pb.WErrorProto, len(subs))
+		for i, sub := range subs {
+			p.SubErrors[i] = ErrToProto(sub)
+		}
+	}
+	return p
+}
+
+// ErrFromProto converts a WErrorProto received from another service to a remote Err (see werror.NewRemoteErr),
+// sub-errors are converted recursively. Metadata is decoded like from JSON, e.g. numbers become float64s.
+// It returns nil if p is nil.
+func ErrFromProto(p *pb.WErrorProto) werror.Err {
+	if p == nil {
+		return nil
+	}
+
+	err := werror.NewRemoteErr(int(p.GetHttpStatus()), werror.ErrCodeString(p.GetCode()), p.GetMessage())
+	if entries := p.GetMetadata(); len(entries) > 0 {
+		err.SetMetadata(metadataFromProto(entries))
+	} else if data := p.GetMetadataJson(); len(data) > 0 {
+		var meta any
+		if json.Unmarshal(data, &meta) == nil {
+			err.SetMetadata(meta)
+		}
+	}
+	for _, sub := range p.GetSubErrors() {
+		err.AddSubErrors(ErrFromProto(sub))
+	}
+	return err
+}
+
+// metadataToProto converts meta to MetadataEntries, entries that cannot be encoded are dropped.
+func metadataToProto(meta map[string]any) []*pb.MetadataEntry {
+	entries := make([]*pb.MetadataEntry, 0, len(meta))
+	backing := make([]pb.MetadataEntry, len(meta))
+	for key, val := range meta {
+		entry := &backing[len(entries)]
+		entry.Key = key
+		switch v := val.(type) {
+		case string:
+			entry.Kind, entry.StringValue = pb.MetadataEntry_KIND_STRING, v
+		case bool:
+			entry.Kind, entry.BoolValue = pb.MetadataEntry_KIND_BOOL, v
+		case float64:
+			entry.Kind, entry.NumberValue = pb.MetadataEntry_KIND_NUMBER, v
+		case int:
+			entry.Kind, entry.NumberValue = pb.MetadataEntry_KIND_NUMBER, float64(v)
+		case int64:
+			entry.Kind, entry.NumberValue = pb.MetadataEntry_KIND_NUMBER, float64(v)
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			entry.JsonValue = data
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// metadataFromProto converts entries back to Metadata, entries whose JSON cannot be decoded are dropped.
+func metadataFromProto(entries []*pb.MetadataEntry) map[string]any {
+	meta := make(map[string]any, len(entries))
+	for _, entry := range entries {
+		switch entry.GetKind() {
+		case pb.MetadataEntry_KIND_STRING:
+			meta[entry.GetKey()] = entry.GetStringValue()
+		case pb.MetadataEntry_KIND_BOOL:
+			meta[entry.GetKey()] = entry.GetBoolValue()
+		case pb.MetadataEntry_KIND_NUMBER:
+			meta[entry.GetKey()] = entry.GetNumberValue()
+		default:
+			var val any
+			if json.Unmarshal(entry.GetJsonValue(), &val) == nil {
+				meta[entry.GetKey()] = val
+			}
+		}
+	}
+	return meta
+}
@@ -0,0 +1,124 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	gproto "google.golang.org/protobuf/proto"
+
+	"github.com/daotl/go-web-common/werror"
+	"github.com/daotl/go-web-common/werror/proto/pb"
+)
+
+func newDetailedErr(details int) werror.Err {
+	err := werror.NewErr(werror.ErrInvalidInput, "Invalid order", "")
+	err.SetMetadata(map[string]any{"orderId": "o-1", "items": []any{"a", "b"}})
+	for i := range details {
+		sub := werror.NewErr(werror.ErrBadArgument, fmt.Sprintf("Item %d is invalid", i), "")
+		sub.SetMetadata(map[string]any{"index": float64(i)})
+		err.AddSubErrors(sub)
+	}
+	return err
+}
+
+func TestRoundTrip(t *testing.T) {
+	orig := newDetailedErr(3)
+	orig.GetSubErrors()[0].AddSubErrors(werror.ErrConflict)
+
+	data, err := gproto.Marshal(ErrToProto(orig))
+	if err != nil {
+		t.Fatalf("proto.Marshal() failed: %v", err)
+	}
+	var p pb.WErrorProto
+	if err := gproto.Unmarshal(data, &p); err != nil {
+		t.Fatalf("proto.Unmarshal() failed: %v", err)
+	}
+	got := ErrFromProto(&p)
+
+	assertEqualErr(t, got, orig)
+	if !werror.IsRemote(got) {
+		t.Error("ErrFromProto() should return a remote Err")
+	}
+}
+
+func assertEqualErr(t *testing.T, got, want werror.Err) {
+	t.Helper()
+
+	if !werror.Equal(got, want) {
+		t.Errorf("got %v (status %d, metadata %v), want %v (status %d, metadata %v)",
+			got, got.GetHttpStatus(), got.GetMetadata(), want, want.GetHttpStatus(), want.GetMetadata())
+	}
+	if len(got.GetSubErrors()) != len(want.GetSubErrors()) {
+		t.Fatalf("got %d sub-errors, want %d", len(got.GetSubErrors()), len(want.GetSubErrors()))
+	}
+	for i := range want.GetSubErrors() {
+		assertEqualErr(t, got.GetSubErrors()[i], want.GetSubErrors()[i])
+	}
+}
+
+func TestNil(t *testing.T) {
+	if p := ErrToProto(nil); p != nil {
+		t.Errorf("ErrToProto(nil) = %v, want nil", p)
+	}
+	if err := ErrFromProto(nil); err != nil {
+		t.Errorf("ErrFromProto(nil) = %v, want nil", err)
+	}
+	if got := ErrFromProto(ErrToProto(werror.ErrNotFound)); !reflect.DeepEqual(got.GetSubErrors(), []werror.Err(nil)) {
+		t.Errorf("GetSubErrors() = %v, want none", got.GetSubErrors())
+	}
+}
+
+func TestRoundTrip_Namespaced(t *testing.T) {
+	orig := werror.NewErrBuilder(werror.ErrNotFound).WithNamespace("billing").Build()
+
+	p := ErrToProto(orig)
+	if p.GetCode() != "billing.NotFound" {
+		t.Errorf("ErrToProto() code = %v, want billing.NotFound", p.GetCode())
+	}
+	got := ErrFromProto(p)
+	if werror.NamespacedCode(got) != "billing.NotFound" || got.GetCode() != werror.CodeNotFound {
+		t.Errorf("ErrFromProto() code = %v (%v), want billing.NotFound (NotFound)",
+			werror.NamespacedCode(got), got.GetCode())
+	}
+}
+
+// TestProtoFasterThanJSON runs BenchmarkProto and BenchmarkJSON and checks that encoding and decoding
+// the wire format is at least 3x faster with proto. The fastest of a few runs is kept to smooth out noise.
+func TestProtoFasterThanJSON(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmark skipped in short mode")
+	}
+	fastest := func(bench func(*testing.B)) int64 {
+		var ns int64
+		for i := range 3 {
+			if got := testing.Benchmark(bench).NsPerOp(); i == 0 || got < ns {
+				ns = got
+			}
+		}
+		return ns
+	}
+	protoNs, jsonNs := fastest(BenchmarkProto), fastest(BenchmarkJSON)
+	if protoNs*3 > jsonNs {
+		t.Errorf("proto takes %d ns/op, JSON %d ns/op, want proto at least 3x faster", protoNs, jsonNs)
+	}
+}
+
+func BenchmarkJSON(b *testing.B) {
+	err := newDetailedErr(10)
+	for b.Loop() {
+		data, _ := json.Marshal(err)
+		var envelope map[string]any
+		_ = json.Unmarshal(data, &envelope)
+	}
+}
+
+func BenchmarkProto(b *testing.B) {
+	err := newDetailedErr(10)
+	for b.Loop() {
+		data, _ := gproto.Marshal(ErrToProto(err))
+		var p pb.WErrorProto
+		_ = gproto.Unmarshal(data, &p)
+	}
+}
@@ -0,0 +1,77 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RateLimitErr is an ErrTooManyRequests telling the client which key, e.g. an IP, user ID or API key,
+// was rate-limited and its quota. WriteError sets the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset headers, and Retry-After until the reset, from it.
+type RateLimitErr struct { //nolint:errname // lib
+	*Serr
+
+	Key       string    `json:"key"       dc:"Rate-limited key, e.g. an IP, user ID or API key"`
+	Limit     int       `json:"limit"     dc:"Maximum number of requests in the window"`
+	Remaining int       `json:"remaining" dc:"Number of requests left in the window"`
+	ResetAt   time.Time `json:"resetAt"   dc:"Time the window resets"`
+}
+
+// NewRateLimitErr creates a RateLimitErr for key, which has remaining of limit requests left until resetAt.
+func NewRateLimitErr(key string, limit, remaining int, resetAt time.Time) *RateLimitErr {
+	err := &RateLimitErr{
+		Serr: &Serr{
+			error:      fmt.Errorf("%w: %s", ErrTooManyRequests, ErrTooManyRequests.GetMessage()),
+			HttpStatus: ErrTooManyRequests.GetHttpStatus(),
+			Code:       ErrTooManyRequests.GetCode(),
+			Message:    ErrTooManyRequests.GetMessage(),
+		},
+		Key:       key,
+		Limit:     limit,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+	}
+	observe(err)
+	return err
+}
+
+func (e *RateLimitErr) Error() string {
+	return fmt.Sprintf("%s (key %s: %d of %d remaining)", e.Serr.Error(), e.Key, e.Remaining, e.Limit)
+}
+
+// Format formats the RateLimitErr like Serr.Format, with its own Error.
+func (e *RateLimitErr) Format(f fmt.State, verb rune) {
+	formatErr(f, verb, e)
+}
+
+// Clone returns a mutable copy of the RateLimitErr.
+func (e *RateLimitErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *RateLimitErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	return &c
+}
+
+// MarshalJSON serializes the RateLimitErr like Serr.MarshalJSON, with the quota in a "rateLimit" object.
+func (e *RateLimitErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *RateLimitErr) jsonValue(ancestors map[*Serr]bool) any {
+	type rateLimit struct {
+		Key       string    `json:"key"`
+		Limit     int       `json:"limit"`
+		Remaining int       `json:"remaining"`
+		ResetAt   time.Time `json:"resetAt"`
+	}
+	return struct {
+		serrJSON
+
+		RateLimit rateLimit `json:"rateLimit"`
+	}{e.toJSON(ancestors), rateLimit{e.Key, e.Limit, e.Remaining, e.ResetAt}}
+}
@@ -0,0 +1,84 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitErr_Headers(t *testing.T) {
+	resetAt := time.Now().Add(30 * time.Second).Truncate(time.Second)
+	tests := []struct {
+		name           string
+		err            error
+		wantRetryAfter bool
+	}{
+		{"NewRateLimitErr", NewRateLimitErr("10.0.0.1", 100, 0, resetAt), true},
+		{"wrapped", fmt.Errorf("limiter: %w", NewRateLimitErr("10.0.0.1", 100, 0, resetAt)), true},
+		{"reset passed", NewRateLimitErr("10.0.0.1", 100, 0, time.Now().Add(-time.Minute)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rerr *RateLimitErr
+			errors.As(tt.err, &rerr)
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+			}
+			wantHeaders := map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(rerr.ResetAt.Unix(), 10),
+			}
+			for key, want := range wantHeaders {
+				if got := rec.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+			retryAfter, _ := strconv.Atoi(rec.Header().Get("Retry-After"))
+			if gotRetryAfter := retryAfter > 0 && retryAfter <= 30; gotRetryAfter != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want set %v", rec.Header().Get("Retry-After"), tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestRateLimitErr(t *testing.T) {
+	resetAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := NewRateLimitErr("user-42", 100, 0, resetAt)
+
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Error("errors.Is(err, ErrTooManyRequests) = false, want true")
+	}
+	if !strings.Contains(err.Error(), "key user-42: 0 of 100 remaining") {
+		t.Errorf("Error() = %q, should include the quota", err.Error())
+	}
+	if rerr, ok := As[*RateLimitErr](err); !ok || rerr != err {
+		t.Error("As[*RateLimitErr]() should find the RateLimitErr")
+	}
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	want := `{"code":"TooManyRequests","message":"Too many requests",` +
+		`"rateLimit":{"key":"user-42","limit":100,"remaining":0,"resetAt":"2026-01-02T03:04:05Z"}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	clone, _ := Clone(err).(*RateLimitErr)
+	clone.Remaining = 5
+	if err.Remaining != 0 {
+		t.Error("modifying a clone should not modify the original")
+	}
+}
@@ -0,0 +1,111 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	h "net/http"
+	"runtime/debug"
+)
+
+// RecoverToErr converts a value recovered from a panic to an Err.
+// A deliberate panic(werr) passes through: if r is or wraps an Err, it is returned as is, preserving its status.
+// Runtime panics, e.g. a write to a nil map, and other values are bugs and become ErrInternalServerError
+// wrapping the recovered value. It returns nil if r is nil.
+func RecoverToErr(r any) Err {
+	if r == nil {
+		return nil
+	}
+
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("panic: %v", r)
+	}
+	var werr Err
+	if errors.As(err, &werr) {
+		return werr
+	}
+	// Unlike NewErrFromError, the panic is not added as a sub-error, so it never reaches the client
+	return observe(&Serr{
+		error:      err,
+		HttpStatus: ErrInternalServerError.GetHttpStatus(),
+		Code:       ErrInternalServerError.GetCode(),
+		Message:    ErrInternalServerError.GetMessage(),
+	})
+}
+
+// PanicRecoveryMiddleware returns a handler that calls next and recovers from its panics, writing the value
+// converted with RecoverToErr with WriteError, so other panics than panic(werr) become ErrInternalServerError
+// without leaking the panic value to the client. Only if verbose responses are enabled (see SetVerbose),
+// the panic value and its stack trace are included as a sub-error of the written Err.
+// Nothing is written if the panic is http.ErrAbortHandler, which is re-panicked to abort the response.
+func PanicRecoveryMiddleware(next h.Handler) h.Handler {
+	return h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == h.ErrAbortHandler {
+				panic(rec)
+			}
+
+			var werr Err
+			if p, ok := rec.(loggedPanic); ok {
+				rec, werr = p.value, p.err
+			} else {
+				werr = RecoverToErr(rec)
+			}
+			if Verbose() && !isErrPanic(rec) {
+				werr = Clone(werr)
+				detail := NewBaseErr(werr.GetHttpStatus(), werr.GetCode(), fmt.Sprint(rec))
+				detail.SetMetadata(map[string]any{"stack": string(debug.Stack())})
+				werr.AddSubErrors(detail)
+			}
+			WriteError(w, r, werr)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Recover returns a handler that calls next and recovers from its panics with PanicRecoveryMiddleware,
+// but first logs the Err converted with RecoverToErr with LogErr to slog.Default, with the panic value
+// and the stack trace.
+func Recover(next h.Handler) h.Handler {
+	return PanicRecoveryMiddleware(h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == h.ErrAbortHandler {
+				panic(rec)
+			}
+			werr := RecoverToErr(rec)
+			LogErr(slog.Default(), werr, "panic recovered",
+				slog.String("panic", fmt.Sprint(rec)), slog.String("stack", string(debug.Stack())))
+			// Re-panicked for PanicRecoveryMiddleware to write the response
+			panic(loggedPanic{value: rec, err: werr})
+		}()
+
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// loggedPanic is re-panicked by Recover with the Err it logged, so that PanicRecoveryMiddleware
+// writes that Err instead of converting the panic value again.
+type loggedPanic struct {
+	value any
+	err   Err
+}
+
+// isErrPanic reports whether the recovered value rec is or wraps an Err, i.e. the panic was deliberate.
+func isErrPanic(rec any) bool {
+	err, ok := rec.(error)
+	if !ok {
+		return false
+	}
+	var werr Err
+	return errors.As(err, &werr)
+}
@@ -0,0 +1,212 @@
+package werror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func recoverFrom(f func()) (werr Err) {
+	defer func() {
+		werr = RecoverToErr(recover())
+	}()
+	f()
+	return nil
+}
+
+func TestRecoverToErr(t *testing.T) {
+	notFound := NewErr(ErrNotFound, "User not found", "")
+
+	tests := []struct {
+		name       string
+		panic      func()
+		want       Err // Returned as is if set
+		wantStatus int
+	}{
+		{"no panic", func() {}, nil, 0},
+		{"Err", func() { panic(notFound) }, notFound, http.StatusNotFound},
+		{"wrapped Err", func() { panic(fmt.Errorf("load: %w", notFound)) }, notFound, http.StatusNotFound},
+		{"nil map write", func() {
+			var m map[string]int
+			m["a"] = 1
+		}, nil, http.StatusInternalServerError},
+		{"string", func() { panic("boom") }, nil, http.StatusInternalServerError},
+		{"plain error", func() { panic(errors.New("boom")) }, nil, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recoverFrom(tt.panic)
+			if tt.wantStatus == 0 {
+				if got != nil {
+					t.Errorf("RecoverToErr() = %v, want nil", got)
+				}
+				return
+			}
+			if tt.want != nil && got != tt.want {
+				t.Errorf("RecoverToErr() = %v, want %v unchanged", got, tt.want)
+			}
+			if tt.want == nil && len(got.GetSubErrors()) > 0 {
+				t.Errorf("GetSubErrors() = %v, the panic must not be exposed", got.GetSubErrors())
+			}
+			if got.GetHttpStatus() != tt.wantStatus {
+				t.Errorf("GetHttpStatus() = %d, want %d", got.GetHttpStatus(), tt.wantStatus)
+			}
+		})
+	}
+
+	var rerr runtime.Error
+	if got := recoverFrom(func() { _ = []int{}[0:1][0] }); !errors.As(got, &rerr) {
+		t.Errorf("RecoverToErr() = %v, should wrap the runtime.Error", got)
+	}
+}
+
+type panicValue struct{ secret string }
+
+func TestPanicRecoveryMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		wantStatus int
+		wantCode   ErrCode
+	}{
+		{"string", "db password is hunter2", http.StatusInternalServerError, CodeInternalServerError},
+		{"error", errors.New("db password is hunter2"), http.StatusInternalServerError, CodeInternalServerError},
+		{"non-error value", panicValue{"hunter2"}, http.StatusInternalServerError, CodeInternalServerError},
+		{"Err", ErrForbidden, http.StatusForbidden, CodeForbidden},
+	}
+	for _, verbose := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s verbose=%v", tt.name, verbose), func(t *testing.T) {
+				saved := Verbose()
+				SetVerbose(verbose)
+				t.Cleanup(func() { SetVerbose(saved) })
+
+				handler := PanicRecoveryMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					panic(tt.value)
+				}))
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				if rec.Code != tt.wantStatus {
+					t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+				}
+				var body struct {
+					Code      ErrCode          `json:"code"`
+					SubErrors []map[string]any `json:"subErrors"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("json.Unmarshal() failed: %v", err)
+				}
+				if body.Code != tt.wantCode {
+					t.Errorf("code = %v, want %v", body.Code, tt.wantCode)
+				}
+				if len(body.SubErrors) > 0 != (verbose && tt.wantCode == CodeInternalServerError) {
+					t.Errorf("sub-errors = %v, want the panic value only in verbose mode", body.SubErrors)
+				}
+				if len(body.SubErrors) > 0 && !strings.Contains(rec.Body.String(), "runtime/debug.Stack") {
+					t.Errorf("body = %s, want the stack trace with the panic value", rec.Body.String())
+				}
+				if leaked := strings.Contains(rec.Body.String(), "hunter2"); leaked && !verbose {
+					t.Errorf("panic value leaked: %s", rec.Body.String())
+				}
+			})
+		}
+	}
+}
+
+func TestPanicRecoveryMiddleware_NoPanic(t *testing.T) {
+	handler := PanicRecoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		wantStatus int
+		wantCode   ErrCode
+	}{
+		{"string", "db password is hunter2", http.StatusInternalServerError, CodeInternalServerError},
+		{"Err", ErrForbidden, http.StatusForbidden, CodeForbidden},
+	}
+	for _, verbose := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s verbose=%v", tt.name, verbose), func(t *testing.T) {
+				saved := Verbose()
+				SetVerbose(verbose)
+				t.Cleanup(func() { SetVerbose(saved) })
+				var logs bytes.Buffer
+				defaultLogger := slog.Default()
+				slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+				t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+				handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					panic(tt.value)
+				}))
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+				if rec.Code != tt.wantStatus {
+					t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+				}
+				if !strings.Contains(rec.Body.String(), `"code":"`+string(tt.wantCode)+`"`) {
+					t.Errorf("body = %s, want code %v", rec.Body.String(), tt.wantCode)
+				}
+				wantStack := verbose && tt.wantCode == CodeInternalServerError
+				if got := strings.Contains(rec.Body.String(), "runtime/debug.Stack"); got != wantStack {
+					t.Errorf("body = %s, want the stack trace only in verbose mode", rec.Body.String())
+				}
+				if leaked := strings.Contains(rec.Body.String(), "hunter2"); leaked && !verbose {
+					t.Errorf("panic value leaked: %s", rec.Body.String())
+				}
+				if !strings.Contains(logs.String(), "panic recovered") || !strings.Contains(logs.String(), "stack=") {
+					t.Errorf("logs = %s, want the panic with the stack trace", logs.String())
+				}
+			})
+		}
+	}
+}
+
+func TestRecover_OnError(t *testing.T) {
+	calls := 0
+	OnError = func(ErrCode, int) { calls++ }
+	t.Cleanup(func() { OnError = nil })
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if calls != 1 {
+		t.Errorf("OnError calls = %d, want 1 for the logged and written Err", calls)
+	}
+}
+
+func TestRecover_AbortHandler(t *testing.T) {
+	handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("recover() = %v, want http.ErrAbortHandler", rec)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,97 @@
+package werror
+
+import (
+	"errors"
+	h "net/http"
+)
+
+// RedactOption selects what Redact removes from an Err.
+type RedactOption func(*Serr)
+
+// WithoutDetails makes Redact remove the sub-errors, see RedactDetails.
+func WithoutDetails() RedactOption {
+	return func(e *Serr) {
+		e.SubErrors = nil
+	}
+}
+
+// WithoutParams makes Redact remove the Metadata, see RedactParams.
+func WithoutParams() RedactOption {
+	return func(e *Serr) {
+		e.Metadata = nil
+	}
+}
+
+// RedactDetails returns a copy of err without sub-errors, which may contain internal information
+// like database queries or hostnames that must not reach clients.
+func RedactDetails(err Err) Err {
+	return Redact(err, WithoutDetails())
+}
+
+// RedactParams returns a copy of err without Metadata.
+func RedactParams(err Err) Err {
+	return Redact(err, WithoutParams())
+}
+
+// Redact returns a copy of err with the parts selected by opts removed, or both its sub-errors and Metadata
+// if no option is given. err itself is not modified, so sentinels can be redacted.
+// The copy is shallow: the sub-errors and Metadata it keeps are shared with err.
+// Errs that do not embed a Serr are wrapped in a new Err with the same status, code and message.
+func Redact(err Err, opts ...RedactOption) Err {
+	if err == nil {
+		return nil
+	}
+	if len(opts) == 0 {
+		opts = []RedactOption{WithoutDetails(), WithoutParams()}
+	}
+	c, serr := shallowCloneSerr(err)
+	for _, opt := range opts {
+		opt(serr)
+	}
+	return c
+}
+
+// redactInternal returns a copy of err without the internal sub-errors created by NewErrFromError anywhere
+// in its tree and true, or err itself and false if it has none. err is not modified. ancestors holds the Errs
+// being walked, whose sub-errors referring back to them are kept as is.
+func redactInternal(err Err, ancestors map[*Serr]bool) (Err, bool) {
+	if !enter(ancestors, err) {
+		return err, false
+	}
+	defer leave(ancestors, err)
+	subs := err.GetSubErrors()
+	var kept []Err
+	for i, sub := range subs {
+		var redacted Err
+		var changed bool
+		if s := serrOf(sub); s != nil && s.internal {
+			changed = true
+		} else {
+			redacted, changed = redactInternal(sub, ancestors)
+		}
+		if changed && kept == nil {
+			kept = append(make([]Err, 0, len(subs)), subs[:i]...)
+		}
+		if kept != nil && redacted != nil {
+			kept = append(kept, redacted)
+		}
+	}
+	if kept == nil {
+		return err, false
+	}
+	c, serr := shallowCloneSerr(err)
+	serr.SubErrors = kept
+	return c, true
+}
+
+// WriteErrorSafe writes err to w like WriteError, but always redacts the sub-errors and Metadata of the Err first.
+func WriteErrorSafe(w h.ResponseWriter, r *h.Request, err error) {
+	if err == nil {
+		return
+	}
+	var werr Err
+	if !errors.As(err, &werr) {
+		werr = ErrInternalServerError
+	}
+	writeError(w, r, Redact(werr))
+}
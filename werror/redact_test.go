@@ -0,0 +1,110 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newSensitiveErr() Err {
+	err := NewErrFromError(ErrInternalServerError, errors.New("SELECT * FROM users on db-1.internal"))
+	err.SetMetadata(map[string]any{"host": "db-1.internal"})
+	return err
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name        string
+		redact      func(Err) Err
+		wantDetails bool
+		wantParams  bool
+	}{
+		{"RedactDetails", RedactDetails, false, true},
+		{"RedactParams", RedactParams, true, false},
+		{"Redact all", func(err Err) Err { return Redact(err) }, false, false},
+		{
+			"Redact with options",
+			func(err Err) Err { return Redact(err, WithoutDetails(), WithoutParams()) },
+			false, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := newSensitiveErr()
+			redacted := tt.redact(orig)
+
+			if got := redacted.GetSubErrors() != nil; got != tt.wantDetails {
+				t.Errorf("redacted has sub-errors = %v, want %v", got, tt.wantDetails)
+			}
+			if got := redacted.GetMetadata() != nil; got != tt.wantParams {
+				t.Errorf("redacted has Metadata = %v, want %v", got, tt.wantParams)
+			}
+			if redacted.GetCode() != orig.GetCode() || redacted.GetMessage() != orig.GetMessage() {
+				t.Errorf("redacted = %v, want code and message of %v", redacted, orig)
+			}
+			if orig.GetSubErrors() == nil || orig.GetMetadata() == nil {
+				t.Error("Redact modified the original Err")
+			}
+		})
+	}
+
+	if Redact(nil) != nil {
+		t.Error("Redact(nil) should return nil")
+	}
+}
+
+func TestWriteErrorSafe(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"Err", newSensitiveErr(), http.StatusInternalServerError},
+		{"Wrapped Err", fmt.Errorf("loading user: %w", newSensitiveErr()), http.StatusInternalServerError},
+		{"Plain error", errors.New("db-1.internal unreachable"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteErrorSafe(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if body := rec.Body.String(); strings.Contains(body, "db-1.internal") || strings.Contains(body, "SELECT") {
+				t.Errorf("body leaks internal details: %s", body)
+			}
+		})
+	}
+}
+
+func TestRedact_Shallow(t *testing.T) {
+	orig := newSensitiveErr()
+	redacted := RedactParams(orig)
+
+	if &redacted.GetSubErrors()[0] != &orig.GetSubErrors()[0] {
+		t.Error("RedactParams() copied the sub-errors, want them shared")
+	}
+	redacted.AddSubErrors(ErrNotFound)
+	if len(orig.GetSubErrors()) != 1 {
+		t.Errorf("AddSubErrors() on the redacted Err modified the original: %v", orig.GetSubErrors())
+	}
+}
+
+func TestWriteError_SelfReferencing(t *testing.T) {
+	saved := Verbose()
+	SetVerbose(false)
+	t.Cleanup(func() { SetVerbose(saved) })
+
+	err := NewErr(ErrBadRequest, "", "")
+	err.AddSubErrors(err)
+
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), err)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
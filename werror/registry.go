@@ -0,0 +1,55 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCodeAlreadyRegistered is returned by RegisterBaseErr when a base Err with the same code is already registered.
+var ErrCodeAlreadyRegistered = errors.New("error code is already registered")
+
+// ErrBaseErrNil is returned by RegisterBaseErr when the base Err is nil.
+var ErrBaseErrNil = errors.New("base Err is nil")
+
+// code2ErrMapMu guards Code2ErrMap.
+var code2ErrMapMu sync.RWMutex
+
+// newCode2ErrMap returns a map from namespaced code to base Err, the first Err wins if codes collide.
+func newCode2ErrMap(errs []Err) map[ErrCode]Err {
+	m := make(map[ErrCode]Err, len(errs))
+	for _, err := range errs {
+		if _, ok := m[NamespacedCode(err)]; !ok {
+			m[NamespacedCode(err)] = err
+		}
+	}
+	return m
+}
+
+// RegisterBaseErr adds a domain-specific base Err, e.g. ErrOrderNotFound, to Code2ErrMap,
+// so that it can be found by its code with LookupByCode, e.g. when decoding an error received as JSON.
+// base should be created with ErrSentinel. Base Errs are registered by their namespaced code (see NamespacedCode),
+// so "order.NotFound" does not collide with ErrNotFound.
+// An error wrapping ErrCodeAlreadyRegistered is returned if its code is taken.
+func RegisterBaseErr(base Err) error {
+	if base == nil {
+		return ErrBaseErrNil
+	}
+
+	code2ErrMapMu.Lock()
+	defer code2ErrMapMu.Unlock()
+	code := NamespacedCode(base)
+	if _, ok := Code2ErrMap[code]; ok {
+		return fmt.Errorf("%w: %s", ErrCodeAlreadyRegistered, code)
+	}
+	Code2ErrMap[code] = base
+	return nil
+}
+
+// LookupByCode returns the base Err with the given namespaced code, e.g. "order.NotFound", from Code2ErrMap.
+func LookupByCode(code ErrCode) (Err, bool) {
+	code2ErrMapMu.RLock()
+	defer code2ErrMapMu.RUnlock()
+	base, ok := Code2ErrMap[code]
+	return base, ok
+}
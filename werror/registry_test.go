@@ -0,0 +1,79 @@
+package werror
+
+import (
+	"errors"
+	h "net/http"
+	"testing"
+)
+
+func TestRegisterBaseErr(t *testing.T) {
+	errOrderNotFound := ErrSentinel(h.StatusNotFound, "OrderNotFound", "Order not found")
+	t.Cleanup(func() {
+		code2ErrMapMu.Lock()
+		delete(Code2ErrMap, errOrderNotFound.GetCode())
+		code2ErrMapMu.Unlock()
+	})
+
+	if _, ok := LookupByCode("OrderNotFound"); ok {
+		t.Fatal("LookupByCode() found OrderNotFound before it was registered")
+	}
+	if err := RegisterBaseErr(errOrderNotFound); err != nil {
+		t.Fatalf("RegisterBaseErr() unexpected error = %v", err)
+	}
+	got, ok := LookupByCode("OrderNotFound")
+	if !ok || got != errOrderNotFound {
+		t.Errorf("LookupByCode() = %v, %v, want %v, true", got, ok, errOrderNotFound)
+	}
+
+	tests := []struct {
+		name string
+		base Err
+		want error
+	}{
+		{"same code again", ErrSentinel(h.StatusGone, "OrderNotFound", "Order is gone"), ErrCodeAlreadyRegistered},
+		{"builtin code", ErrSentinel(h.StatusNotFound, CodeNotFound, "Not found"), ErrCodeAlreadyRegistered},
+		{"nil", nil, ErrBaseErrNil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterBaseErr(tt.base); !errors.Is(err, tt.want) {
+				t.Errorf("RegisterBaseErr() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if got, _ := LookupByCode("OrderNotFound"); got != errOrderNotFound {
+		t.Errorf("LookupByCode() = %v after collision, want the first registered %v", got, errOrderNotFound)
+	}
+}
+
+func TestLookupByCode(t *testing.T) {
+	for _, base := range baseErrs {
+		got, ok := LookupByCode(base.GetCode())
+		if !ok || got != base {
+			t.Errorf("LookupByCode(%s) = %v, %v, want %v, true", base.GetCode(), got, ok, base)
+		}
+	}
+	if got, ok := LookupByCode("NoSuchCode"); ok {
+		t.Errorf("LookupByCode(NoSuchCode) = %v, true, want nil, false", got)
+	}
+}
+
+func TestRegisterBaseErr_Namespaced(t *testing.T) {
+	errOrderNotFound := NewErrBuilder(ErrNotFound).WithNamespace("order").Build()
+	t.Cleanup(func() {
+		code2ErrMapMu.Lock()
+		delete(Code2ErrMap, "order.NotFound")
+		code2ErrMapMu.Unlock()
+	})
+
+	if err := RegisterBaseErr(errOrderNotFound); err != nil {
+		t.Fatalf("RegisterBaseErr() error = %v, want no collision with ErrNotFound", err)
+	}
+	if got, ok := LookupByCode("order.NotFound"); !ok || got != errOrderNotFound {
+		t.Errorf("LookupByCode(order.NotFound) = %v, %v, want %v, true", got, ok, errOrderNotFound)
+	}
+	if got, _ := LookupByCode(CodeNotFound); got != ErrNotFound {
+		t.Errorf("LookupByCode(NotFound) = %v, want ErrNotFound", got)
+	}
+}
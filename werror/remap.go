@@ -0,0 +1,49 @@
+package werror
+
+import (
+	h "net/http"
+	"sync"
+)
+
+// CodeRemapper remaps the HTTP statuses errors are written with, e.g. every 500 to 200 for deployments behind
+// proxies replacing the bodies of 5xx responses. Only the status changes, the code and the rest of the body
+// are kept. The zero value is ready to use, and it is safe for concurrent use.
+type CodeRemapper struct {
+	mu       sync.RWMutex
+	statuses map[int]int
+}
+
+// Register makes Remap change the status from to the status to.
+func (m *CodeRemapper) Register(from, to int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.statuses == nil {
+		m.statuses = map[int]int{}
+	}
+	m.statuses[from] = to
+}
+
+// Remap returns a copy of err with its status remapped, or err itself if its status is not registered.
+// err itself is not modified. Errs that do not embed a Serr are wrapped in a new Err with the same code and message.
+func (m *CodeRemapper) Remap(err Err) Err {
+	if err == nil {
+		return nil
+	}
+	m.mu.RLock()
+	to, ok := m.statuses[err.GetHttpStatus()]
+	m.mu.RUnlock()
+	if !ok {
+		return err
+	}
+	c, serr := cloneSerr(err)
+	serr.HttpStatus = to
+	return c
+}
+
+// WrapWriteError writes err to w like WriteError, with the status remapped by remapper.
+func WrapWriteError(remapper *CodeRemapper, w h.ResponseWriter, err Err) {
+	if err == nil {
+		return
+	}
+	writeError(w, nil, remapper.Remap(err))
+}
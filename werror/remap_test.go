@@ -0,0 +1,59 @@
+package werror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCodeRemapper(t *testing.T) {
+	var remapper CodeRemapper
+	remapper.Register(http.StatusInternalServerError, http.StatusOK)
+	remapper.Register(http.StatusServiceUnavailable, http.StatusOK)
+
+	tests := []struct {
+		name       string
+		err        Err
+		wantStatus int
+	}{
+		{"Remapped 500", ErrInternalServerError, http.StatusOK},
+		{"Remapped 503", NewErr(ErrServiceUnavailable, "", "maintenance"), http.StatusOK},
+		{"Not registered", ErrNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.err.GetHttpStatus()
+
+			rec := httptest.NewRecorder()
+			WrapWriteError(&remapper, rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), `"code":"`+string(tt.err.GetCode())+`"`) {
+				t.Errorf("body = %s, want the code of the Err", rec.Body.String())
+			}
+			if tt.err.GetHttpStatus() != status {
+				t.Error("WrapWriteError() should not modify the Err")
+			}
+		})
+	}
+}
+
+func TestCodeRemapper_Remap(t *testing.T) {
+	var remapper CodeRemapper
+	if got := remapper.Remap(ErrInternalServerError); got != ErrInternalServerError {
+		t.Errorf("Remap() of the zero CodeRemapper = %v, want the Err itself", got)
+	}
+	if got := remapper.Remap(nil); got != nil {
+		t.Errorf("Remap(nil) = %v, want nil", got)
+	}
+
+	remapper.Register(http.StatusInternalServerError, http.StatusOK)
+	got := remapper.Remap(ErrInternalServerError)
+	if got.GetHttpStatus() != http.StatusOK || got.GetCode() != CodeInternalServerError {
+		t.Errorf("Remap() = %v %v, want %v %v",
+			got.GetHttpStatus(), got.GetCode(), http.StatusOK, CodeInternalServerError)
+	}
+}
@@ -0,0 +1,75 @@
+package werror
+
+// SanitizeOption configures SanitizeErr.
+type SanitizeOption func(*sanitizeConfig)
+
+type sanitizeConfig struct {
+	allowedParams map[string]bool
+}
+
+// AllowParam makes SanitizeErr keep the Metadata entry key, e.g. "resource_id", if the Metadata is a map[string]any.
+func AllowParam(key string) SanitizeOption {
+	return func(c *sanitizeConfig) {
+		if c.allowedParams == nil {
+			c.allowedParams = map[string]bool{}
+		}
+		c.allowedParams[key] = true
+	}
+}
+
+// SanitizeErr returns a copy of err and its sub-errors without Metadata, which may contain internal information
+// like SQL query parameters or file paths, so it can be marshaled into API responses.
+// Entries of map[string]any Metadata allowed with AllowParam are kept. err itself is not modified.
+// Errs that do not embed a Serr are wrapped in a new Err with the same status, code and message.
+func SanitizeErr(err Err, opts ...SanitizeOption) Err {
+	var cfg sanitizeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return sanitizeErr(err, &cfg, map[*Serr]Err{})
+}
+
+// sanitizeErr sanitizes err for SanitizeErr. copies are the sanitized copies already made of the Serrs being
+// sanitized, so an Err that is its own (transitive) sub-error is copied to one that is its copy's sub-error.
+func sanitizeErr(err Err, cfg *sanitizeConfig, copies map[*Serr]Err) Err {
+	if err == nil {
+		return nil
+	}
+	if c, ok := copies[serrOf(err)]; ok {
+		return c
+	}
+	c, serr := shallowCloneSerr(err)
+	if s := serrOf(err); s != nil {
+		copies[s] = c
+	}
+	serr.Metadata = cfg.allowedMetadata(serr.Metadata)
+	if serr.SubErrors != nil {
+		subs := make([]Err, len(serr.SubErrors))
+		for i, sub := range serr.SubErrors {
+			subs[i] = sanitizeErr(sub, cfg, copies)
+		}
+		serr.SubErrors = subs
+	}
+	return c
+}
+
+// allowedMetadata returns the allowed entries of meta, nil if there are none.
+func (c *sanitizeConfig) allowedMetadata(meta any) any {
+	params, ok := meta.(map[string]any)
+	if !ok || len(c.allowedParams) == 0 {
+		return nil
+	}
+	var allowed map[string]any
+	for key, v := range params {
+		if c.allowedParams[key] {
+			if allowed == nil {
+				allowed = map[string]any{}
+			}
+			allowed[key] = cloneValue(v)
+		}
+	}
+	if allowed == nil {
+		return nil
+	}
+	return allowed
+}
@@ -0,0 +1,76 @@
+package werror
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSanitizeErr(t *testing.T) {
+	newErr := func() Err {
+		err := NewErr(ErrNotFound, "", "")
+		err.SetMetadata(map[string]any{"sql_query": "SELECT * FROM users", "resource_id": "42"})
+		sub := NewErr(ErrConflict, "", "")
+		sub.SetMetadata(map[string]any{"resource_id": "43", "path": "/etc/app.conf"})
+		err.AddSubErrors(sub)
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		opts     []SanitizeOption
+		wantJSON string
+	}{
+		{
+			name: "No params allowed",
+			wantJSON: `{"code":"NotFound","message":"Not found",` +
+				`"subErrors":[{"code":"Conflict","message":"Conflict"}]}`,
+		},
+		{
+			name: "resource_id allowed",
+			opts: []SanitizeOption{AllowParam("resource_id")},
+			wantJSON: `{"code":"NotFound","message":"Not found",` +
+				`"subErrors":[{"code":"Conflict","message":"Conflict","metadata":{"resource_id":"43"}}],` +
+				`"metadata":{"resource_id":"42"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newErr()
+			sanitized := SanitizeErr(err, tt.opts...)
+
+			data, jerr := json.Marshal(sanitized)
+			if jerr != nil {
+				t.Fatalf("json.Marshal() failed: %v", jerr)
+			}
+			if string(data) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.wantJSON)
+			}
+			if meta, _ := err.GetMetadata().(map[string]any); meta["sql_query"] == nil {
+				t.Error("SanitizeErr() should not modify the original")
+			}
+			if meta, _ := err.GetSubErrors()[0].GetMetadata().(map[string]any); meta["path"] == nil {
+				t.Error("SanitizeErr() should not modify the original sub-errors")
+			}
+		})
+	}
+}
+
+func TestSanitizeErr_Nil(t *testing.T) {
+	if got := SanitizeErr(nil); got != nil {
+		t.Errorf("SanitizeErr(nil) = %v, want nil", got)
+	}
+}
+
+func TestSanitizeErr_SelfReferencing(t *testing.T) {
+	err := newCyclicErr()
+	err.SetMetadata(map[string]any{"query": "SELECT 1"})
+
+	sanitized := SanitizeErr(err)
+	subs := sanitized.GetSubErrors()
+	if len(subs) != 2 || subs[0] != sanitized || subs[1].GetSubErrors()[0] != sanitized {
+		t.Fatalf("SanitizeErr() sub-errors = %v, want the sanitized Err itself, directly and through mid", subs)
+	}
+	if sanitized.GetMetadata() != nil || err.GetMetadata() == nil {
+		t.Errorf("SanitizeErr() Metadata = %v, original %v", sanitized.GetMetadata(), err.GetMetadata())
+	}
+}
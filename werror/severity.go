@@ -0,0 +1,71 @@
+package werror
+
+import (
+	h "net/http"
+)
+
+// Severity tells how serious an Err is for alerting, e.g. to page on SeverityError and above
+// while only showing SeverityWarning on dashboards.
+type Severity int
+
+// Severities from the least to the most serious. The zero value means the severity derives from the status,
+// see Serr.GetSeverity.
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// String returns the lower-case name of the severity, e.g. "warning", or "unknown".
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// GetSeverity returns the severity set with WithSeverity, or by default SeverityWarning for 4xx statuses,
+// which are caused by the client, SeverityError for 5xx statuses and SeverityInfo otherwise.
+func (e *Serr) GetSeverity() Severity {
+	if e.severity != 0 {
+		return e.severity
+	}
+	return statusSeverity(e.HttpStatus)
+}
+
+// WithSeverity returns a mutable copy of the Serr with the given severity, the Serr itself is not modified.
+func (e *Serr) WithSeverity(s Severity) *Serr {
+	//nolint:errcheck // type must match
+	c := e.Clone().(*Serr)
+	c.severity = s
+	return c
+}
+
+// SeverityOf returns the severity of err, see Serr.GetSeverity.
+// Errs without GetSeverity method get the default severity of their status.
+func SeverityOf(err Err) Severity {
+	if s, ok := err.(interface{ GetSeverity() Severity }); ok {
+		return s.GetSeverity()
+	}
+	return statusSeverity(err.GetHttpStatus())
+}
+
+func statusSeverity(status int) Severity {
+	switch {
+	case status >= h.StatusInternalServerError:
+		return SeverityError
+	case status >= h.StatusBadRequest:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
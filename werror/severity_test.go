@@ -0,0 +1,48 @@
+package werror
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Err
+		want Severity
+	}{
+		{"4xx", ErrNotFound, SeverityWarning},
+		{"5xx", ErrInternalServerError, SeverityError},
+		{"2xx", NewBaseErr(http.StatusOK, "Done", "Done"), SeverityInfo},
+		{"Override", ErrServiceUnavailable.(*Serr).WithSeverity(SeverityCritical), SeverityCritical},
+		{"Embedding type", NewThrottleErr(1, 2, 3), SeverityWarning},
+		{"Chain of the outermost Err", ErrChain(ErrBadRequest, ErrInternalServerError), SeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeverityOf(tt.err); got != tt.want {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerr_WithSeverity(t *testing.T) {
+	//nolint:errcheck // type must match
+	critical := ErrServiceUnavailable.(*Serr).WithSeverity(SeverityCritical)
+
+	if critical.GetSeverity() != SeverityCritical || critical.GetCode() != CodeServiceUnavailable {
+		t.Errorf("WithSeverity() = %v %v, want %v %v",
+			critical.GetSeverity(), critical.GetCode(), SeverityCritical, CodeServiceUnavailable)
+	}
+	//nolint:errcheck // type must match
+	if got := ErrServiceUnavailable.(*Serr).GetSeverity(); got != SeverityError {
+		t.Errorf("ErrServiceUnavailable severity changed to %v", got)
+	}
+	if got := Clone(critical).(*Serr).GetSeverity(); got != SeverityCritical {
+		t.Errorf("Clone() severity = %v, want %v", got, SeverityCritical)
+	}
+	if got := Severity(0).String(); got != "unknown" {
+		t.Errorf("Severity(0).String() = %q, want unknown", got)
+	}
+}
@@ -0,0 +1,131 @@
+package werror
+
+import (
+	h "net/http"
+	"sync"
+	"time"
+)
+
+// statsBuckets is the number of one-second buckets kept by respStatsRecorder, i.e. the maximum stats window.
+const statsBuckets = 600
+
+// respStats records the statuses of the responses written through StatsMiddleware.
+var respStats = &respStatsRecorder{}
+
+// respStatsRecorder counts responses in one-second buckets over the last statsBuckets seconds.
+type respStatsRecorder struct {
+	mu      sync.Mutex
+	buckets [statsBuckets]respStatsBucket
+}
+
+type respStatsBucket struct {
+	// Unix second the counts belong to.
+	sec          int64
+	total        int
+	serverErrors int
+}
+
+// record counts a response with status written at now.
+func (s *respStatsRecorder) record(now time.Time, status int) {
+	sec := now.Unix()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := &s.buckets[sec%statsBuckets]
+	if b.sec != sec {
+		*b = respStatsBucket{sec: sec}
+	}
+	b.total++
+	if status >= h.StatusInternalServerError {
+		b.serverErrors++
+	}
+}
+
+// serverErrorRatio returns the ratio of 5xx among the responses recorded in the window before now,
+// 0 if there are none, and the number of responses. The window is capped at statsBuckets seconds.
+func (s *respStatsRecorder) serverErrorRatio(now time.Time, window time.Duration) (float64, int) {
+	from := now.Add(-window).Unix()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var total, serverErrors int
+	for _, b := range s.buckets {
+		if b.sec > from && b.sec <= now.Unix() {
+			total += b.total
+			serverErrors += b.serverErrors
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return float64(serverErrors) / float64(total), total
+}
+
+// statsResponseWriter captures the status written to the wrapped ResponseWriter for StatsMiddleware.
+type statsResponseWriter struct {
+	h.ResponseWriter
+
+	status int
+	// Set by ReadinessHandler so that probes are not recorded.
+	skip bool
+}
+
+func (w *statsResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statsResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = h.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped ResponseWriter for http.ResponseController.
+func (w *statsResponseWriter) Unwrap() h.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// StatsMiddleware returns a handler that calls next and records the status of every response for ReadinessHandler,
+// 200 if next writes nothing. It should wrap all routes, so that the 5xx ratio is over all requests.
+func StatsMiddleware(next h.Handler) h.Handler {
+	return h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		sw := &statsResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.skip {
+			return
+		}
+		if sw.status == 0 {
+			sw.status = h.StatusOK
+		}
+		respStats.record(time.Now(), sw.status)
+	})
+}
+
+// ReadinessHandler returns a readiness probe handler that responds with ErrServiceUnavailable
+// when the ratio of 5xx among the responses recorded within window exceeds threshold, so that the instance
+// is drained, and with status 200 otherwise. The window is capped at 10 minutes.
+// Responses are only recorded by StatsMiddleware, which must wrap all routes, including the probe itself so that
+// probes are not recorded: without it nothing is recorded and the probe always responds with status 200.
+// A single failing request counts, see ReadinessHandlerWithMin to require a minimum sample.
+func ReadinessHandler(threshold float64, window time.Duration) h.Handler {
+	return ReadinessHandlerWithMin(threshold, window, 1)
+}
+
+// ReadinessHandlerWithMin is like ReadinessHandler, but the ratio is only considered once at least minRequests
+// responses were recorded within window, so a single failing request after a quiet period does not drain the instance.
+func ReadinessHandlerWithMin(threshold float64, window time.Duration, minRequests int) h.Handler {
+	return h.HandlerFunc(func(w h.ResponseWriter, r *h.Request) {
+		if sw, ok := w.(*statsResponseWriter); ok {
+			// Or failing probes would keep the instance unready
+			sw.skip = true
+		}
+		ratio, total := respStats.serverErrorRatio(time.Now(), window)
+		if total >= max(minRequests, 1) && ratio > threshold {
+			WriteError(w, r, ErrServiceUnavailable)
+			return
+		}
+		w.WriteHeader(h.StatusOK)
+	})
+}
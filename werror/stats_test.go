@@ -0,0 +1,111 @@
+package werror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		minRequests int
+		wantStatus  int
+	}{
+		{"no requests", nil, 1, http.StatusOK},
+		{
+			"low 5xx rate",
+			[]int{http.StatusOK, http.StatusNotFound, http.StatusInternalServerError, http.StatusOK},
+			1,
+			http.StatusOK,
+		},
+		{
+			"high 5xx rate among errors, low among requests",
+			[]int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusNotFound, http.StatusInternalServerError},
+			1,
+			http.StatusOK,
+		},
+		{
+			"high 5xx rate",
+			[]int{http.StatusOK, http.StatusInternalServerError, http.StatusServiceUnavailable},
+			1,
+			http.StatusServiceUnavailable,
+		},
+		{"below the minimum sample size", []int{http.StatusInternalServerError}, 10, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := respStats
+			respStats = &respStatsRecorder{}
+			t.Cleanup(func() { respStats = saved })
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+				if s, _ := strconv.Atoi(r.URL.Query().Get("s")); s >= http.StatusBadRequest {
+					WriteError(w, r, StatusToErr(s))
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			})
+			readiness := ReadinessHandler(0.5, time.Minute)
+			if tt.minRequests > 1 {
+				readiness = ReadinessHandlerWithMin(0.5, time.Minute, tt.minRequests)
+			}
+			mux.Handle("/readyz", readiness)
+			handler := StatsMiddleware(mux)
+
+			for _, status := range tt.statuses {
+				req := httptest.NewRequest(http.MethodGet, "/status?s="+strconv.Itoa(status), nil)
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+			for range 2 { // A failing probe must not count itself
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+				if rec.Code != tt.wantStatus {
+					t.Errorf("ReadinessHandler() status = %d, want %d", rec.Code, tt.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsMiddleware(t *testing.T) {
+	saved := respStats
+	respStats = &respStatsRecorder{}
+	t.Cleanup(func() { respStats = saved })
+
+	handlers := []http.HandlerFunc{
+		func(http.ResponseWriter, *http.Request) {},
+		func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) },
+		func(w http.ResponseWriter, r *http.Request) { WriteError(w, r, ErrServiceUnavailable) },
+		func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+	}
+	for _, next := range handlers {
+		StatsMiddleware(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	if ratio, total := respStats.serverErrorRatio(time.Now(), time.Minute); ratio != 0.5 || total != 4 {
+		t.Errorf("serverErrorRatio() = %v, %d, want 0.5, 4", ratio, total)
+	}
+}
+
+func TestRespStatsRecorder_Window(t *testing.T) {
+	s := &respStatsRecorder{}
+	now := time.Now()
+	s.record(now.Add(-2*time.Minute), http.StatusInternalServerError)
+	s.record(now.Add(-10*time.Second), http.StatusNotFound)
+	s.record(now, http.StatusBadGateway)
+
+	if got, _ := s.serverErrorRatio(now, time.Minute); got != 0.5 {
+		t.Errorf("serverErrorRatio(1m) = %v, want 0.5", got)
+	}
+	if got, total := s.serverErrorRatio(now, 5*time.Minute); got != 2.0/3 || total != 3 {
+		t.Errorf("serverErrorRatio(5m) = %v, %d, want 2/3, 3", got, total)
+	}
+	if got, total := s.serverErrorRatio(now.Add(time.Hour), time.Minute); got != 0 || total != 0 {
+		t.Errorf("serverErrorRatio() after the window = %v, %d, want 0, 0", got, total)
+	}
+}
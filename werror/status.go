@@ -0,0 +1,31 @@
+package werror
+
+import "errors"
+
+// StatusClass returns the class of the HTTP status of the outermost Err in err's chain, the one WriteError
+// responds with: 4 for client errors (400-499), 5 for server errors (500-599), and 0 otherwise,
+// e.g. if err wraps no Err or an Err with status 200 by accident.
+func StatusClass(err error) int {
+	var werr Err
+	if !errors.As(err, &werr) {
+		return 0
+	}
+	switch status := werr.GetHttpStatus(); {
+	case status >= 400 && status < 500:
+		return 4
+	case status >= 500 && status < 600:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// IsClientError reports whether the outermost Err in err's chain has a 4xx status, see StatusClass.
+func IsClientError(err error) bool {
+	return StatusClass(err) == 4
+}
+
+// IsServerError reports whether the outermost Err in err's chain has a 5xx status, see StatusClass.
+func IsServerError(err error) bool {
+	return StatusClass(err) == 5
+}
@@ -0,0 +1,45 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       int
+		wantClient bool
+		wantServer bool
+	}{
+		{"4xx", ErrNotFound, 4, true, false},
+		{"5xx", ErrServiceUnavailable, 5, false, true},
+		{"client closed request", ErrClientClosedRequest, 4, true, false},
+		{"wrapped", fmt.Errorf("load: %w", ErrConflict), 4, true, false},
+		{"outermost Err wins", NewErrFromError(ErrInternalServerError, ErrNotFound), 5, false, true},
+		{
+			"outermost Err in a chain",
+			fmt.Errorf("load: %w", NewErrFromError(ErrBadRequest, ErrServiceUnavailable)), 4, true, false,
+		},
+		{"200 by accident", NewBaseErr(http.StatusOK, "OK", "OK"), 0, false, false},
+		{"599", NewBaseErr(599, "Custom", "Custom"), 5, false, true},
+		{"standard error", errors.New("boom"), 0, false, false},
+		{"nil", nil, 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusClass(tt.err); got != tt.want {
+				t.Errorf("StatusClass() = %d, want %d", got, tt.want)
+			}
+			if got := IsClientError(tt.err); got != tt.wantClient {
+				t.Errorf("IsClientError() = %v, want %v", got, tt.wantClient)
+			}
+			if got := IsServerError(tt.err); got != tt.wantServer {
+				t.Errorf("IsServerError() = %v, want %v", got, tt.wantServer)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package testutil provides test assertions for werror errors.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// AssertErrEqual fails the test if got and want differ in code, HTTP status or message, see werror.ErrEqual.
+func AssertErrEqual(t testing.TB, got, want werror.Err) {
+	t.Helper()
+	if werror.ErrEqual(got, want) {
+		return
+	}
+	if got == nil || want == nil {
+		t.Errorf("Err = %v, want %v", got, want)
+		return
+	}
+	t.Errorf("Err mismatch:\n  code:    %v, want %v\n  status:  %v, want %v\n  message: %q, want %q",
+		got.GetCode(), want.GetCode(),
+		got.GetHttpStatus(), want.GetHttpStatus(),
+		got.GetMessage(), want.GetMessage())
+}
+
+// AssertErrCode fails the test if got is nil or its code is not code.
+func AssertErrCode(t testing.TB, got werror.Err, code werror.ErrCode) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("Err = nil, want code %v", code)
+		return
+	}
+	if got.GetCode() != code {
+		t.Errorf("Err code = %v, want %v (message %q)", got.GetCode(), code, got.GetMessage())
+	}
+}
@@ -0,0 +1,84 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// recordingTB records the failures reported through it instead of failing the test.
+type recordingTB struct {
+	testing.TB
+
+	helper bool
+	errors []string
+}
+
+func (tb *recordingTB) Helper() {
+	tb.helper = true
+}
+
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertErrEqual(t *testing.T) {
+	userNotFound := werror.NewErr(werror.ErrNotFound, "User not found", "")
+
+	tests := []struct {
+		name     string
+		got      werror.Err
+		want     werror.Err
+		wantFail string
+	}{
+		{"equal", userNotFound, werror.NewErr(werror.ErrNotFound, "User not found", ""), ""},
+		{"different message", userNotFound, werror.ErrNotFound, `message: "User not found", want "Not found"`},
+		{"different code", werror.ErrConflict, werror.ErrNotFound, "code:    Conflict, want NotFound"},
+		{"nil", nil, werror.ErrNotFound, "Err = <nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{}
+			AssertErrEqual(tb, tt.got, tt.want)
+			checkFailure(t, tb, tt.wantFail)
+		})
+	}
+}
+
+func TestAssertErrCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      werror.Err
+		code     werror.ErrCode
+		wantFail string
+	}{
+		{"matching code", werror.NewErr(werror.ErrNotFound, "User not found", ""), werror.CodeNotFound, ""},
+		{"different code", werror.ErrConflict, werror.CodeNotFound, "Err code = Conflict, want NotFound"},
+		{"nil", nil, werror.CodeNotFound, "Err = nil, want code NotFound"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{}
+			AssertErrCode(tb, tt.got, tt.code)
+			checkFailure(t, tb, tt.wantFail)
+		})
+	}
+}
+
+func checkFailure(t *testing.T, tb *recordingTB, wantFail string) {
+	t.Helper()
+	if !tb.helper {
+		t.Error("assertion should call t.Helper()")
+	}
+	if wantFail == "" {
+		if len(tb.errors) > 0 {
+			t.Errorf("assertion failed unexpectedly: %v", tb.errors)
+		}
+		return
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], wantFail) {
+		t.Errorf("failures = %q, want one containing %q", tb.errors, wantFail)
+	}
+}
@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatText formats err as plain text for CLIs and log lines, "[STATUS CODE] MESSAGE" followed by a line per
+// sub-error, indented by two spaces per level, e.g.
+//
+//	[400 BadRequest] Bad request
+//	  [400 InvalidInput] Name is required
+//
+// It returns an empty string if err is nil.
+func FormatText(err Err) string {
+	return formatText(err, false)
+}
+
+// FormatTextVerbose is like FormatText but also writes the params of every Err on a line below it.
+func FormatTextVerbose(err Err) string {
+	return formatText(err, true)
+}
+
+func formatText(err Err, verbose bool) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	writeText(&b, err, 0, verbose, map[*Serr]bool{})
+	return b.String()
+}
+
+// writeText writes err and its sub-errors for formatText. ancestors are the Serrs of the Errs containing err as a
+// (transitive) sub-error, a sub-error that is its own ancestor is written as "[CyclicReference]".
+func writeText(b *strings.Builder, err Err, depth int, verbose bool, ancestors map[*Serr]bool) {
+	indent := strings.Repeat("  ", depth)
+	if !enter(ancestors, err) {
+		fmt.Fprintf(b, "%s[%s]", indent, CodeCyclicReference)
+		return
+	}
+	defer leave(ancestors, err)
+	fmt.Fprintf(b, "%s[%d %s] %s", indent, err.GetHttpStatus(), NamespacedCode(err), err.GetMessage())
+	if verbose {
+		if params := formatMetadata(err.GetMetadata()); params != "" {
+			fmt.Fprintf(b, "\n%s  params: %s", indent, params)
+		}
+	}
+	for _, sub := range err.GetSubErrors() {
+		if sub != nil {
+			b.WriteString("\n")
+			writeText(b, sub, depth+1, verbose, ancestors)
+		}
+	}
+}
+
+// TextEncoder streams Err formatted by FormatText, or FormatTextVerbose if Verbose is set, terminated by a newline.
+type TextEncoder struct {
+	Err     Err
+	Verbose bool
+}
+
+// WriteTo implements io.WriterTo, it writes nothing if Err is nil.
+func (e TextEncoder) WriteTo(w io.Writer) (int64, error) {
+	if e.Err == nil {
+		return 0, nil
+	}
+	n, err := io.WriteString(w, formatText(e.Err, e.Verbose)+"\n")
+	return int64(n), err
+}
@@ -0,0 +1,69 @@
+package werror
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatText(t *testing.T) {
+	badRequest := NewErrBuilder(ErrBadRequest).
+		WithDetails(NewErr(ErrInvalidInput, "Name is required", ""), ErrNotFound).
+		WithParam("userId", 7).
+		Build()
+
+	tests := []struct {
+		name        string
+		err         Err
+		want        string
+		wantVerbose string
+	}{
+		{"Nil", nil, "", ""},
+		{
+			"No details",
+			ErrInternalServerError,
+			"[500 InternalServerError] The server encountered an internal error, please retry the request",
+			"[500 InternalServerError] The server encountered an internal error, please retry the request",
+		},
+		{
+			"Details and params",
+			badRequest,
+			"[400 BadRequest] Bad request\n  [400 InvalidInput] Name is required\n  [404 NotFound] Not found",
+			"[400 BadRequest] Bad request\n  params: {userId:7}\n" +
+				"  [400 InvalidInput] Name is required\n  [404 NotFound] Not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatText(tt.err); got != tt.want {
+				t.Errorf("FormatText() = %q, want %q", got, tt.want)
+			}
+			if got := FormatTextVerbose(tt.err); got != tt.wantVerbose {
+				t.Errorf("FormatTextVerbose() = %q, want %q", got, tt.wantVerbose)
+			}
+		})
+	}
+}
+
+func TestTextEncoder_WriteTo(t *testing.T) {
+	var b strings.Builder
+	n, err := TextEncoder{Err: ErrNotFound}.WriteTo(&b)
+	if want := "[404 NotFound] Not found\n"; err != nil || b.String() != want || n != int64(len(want)) {
+		t.Errorf("WriteTo() = %d, %v, wrote %q, want %q", n, err, b.String(), want)
+	}
+
+	b.Reset()
+	if n, err := (TextEncoder{}).WriteTo(&b); n != 0 || err != nil || b.Len() != 0 {
+		t.Errorf("WriteTo() of nil Err = %d, %v, wrote %q", n, err, b.String())
+	}
+}
+
+func TestFormatText_SelfReferencing(t *testing.T) {
+	want := "[400 BadRequest] Bad request\n" +
+		"  [CyclicReference]\n" +
+		"  [404 NotFound] Not found\n" +
+		"    [CyclicReference]\n" +
+		"    [409 Conflict] Conflict"
+	if got := FormatText(newCyclicErr()); got != want {
+		t.Errorf("FormatText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+	h "net/http"
+)
+
+// ThrottleErr is an ErrTooManyRequests telling the client how long to wait before retrying
+// and how the wait grows with each retry. WriteError sets the Retry-After header from it.
+type ThrottleErr struct { //nolint:errname // lib
+	*Serr
+
+	RetryAfterSeconds int     `json:"retryAfterSeconds" dc:"Seconds to wait before retrying"`
+	BackoffMultiplier float64 `json:"backoffMultiplier" dc:"Factor the wait grows by with each retry"`
+	MaxRetries        int     `json:"maxRetries"        dc:"Maximum number of retries"`
+}
+
+// ErrThrottle is the canonical ThrottleErr for status 429 in HttpStatus2ErrMap, asking the client to retry
+// after a second and to double the wait for each of at most 5 retries. Like the other base Errs it is frozen;
+// it has its own Serr with the code and message of ErrTooManyRequests. Use NewThrottleErr for other waits.
+var ErrThrottle = &ThrottleErr{
+	Serr: &Serr{
+		error:      fmt.Errorf("%s %s", CodeTooManyRequests, ErrTooManyRequests.GetMessage()),
+		HttpStatus: h.StatusTooManyRequests,
+		Code:       CodeTooManyRequests,
+		Message:    ErrTooManyRequests.GetMessage(),
+		frozen:     true,
+	},
+	RetryAfterSeconds: 1,
+	BackoffMultiplier: 2,
+	MaxRetries:        5,
+}
+
+// NewThrottleErr creates a ThrottleErr asking the client to retry after retryAfter seconds,
+// multiplying the wait by backoff for each of at most maxRetries retries.
+// Each call returns a new ThrottleErr around a mutable clone of ErrTooManyRequests.
+func NewThrottleErr(retryAfter int, backoff float64, maxRetries int) *ThrottleErr {
+	err := &ThrottleErr{
+		//nolint:errcheck // type must match
+		Serr:              Clone(ErrTooManyRequests).(*Serr),
+		RetryAfterSeconds: retryAfter,
+		BackoffMultiplier: backoff,
+		MaxRetries:        maxRetries,
+	}
+	observe(err)
+	return err
+}
+
+func (e *ThrottleErr) Error() string {
+	return fmt.Sprintf("%s (retry after %ds)", e.Serr.Error(), e.RetryAfterSeconds)
+}
+
+// Format formats the ThrottleErr like Serr.Format, with its own Error.
+func (e *ThrottleErr) Format(f fmt.State, verb rune) {
+	formatErr(f, verb, e)
+}
+
+// Clone returns a mutable copy of the ThrottleErr.
+func (e *ThrottleErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *ThrottleErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	return &c
+}
+
+// MarshalJSON serializes the ThrottleErr like Serr.MarshalJSON, with the retry fields.
+func (e *ThrottleErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *ThrottleErr) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		RetryAfterSeconds int     `json:"retryAfterSeconds"`
+		BackoffMultiplier float64 `json:"backoffMultiplier"`
+		MaxRetries        int     `json:"maxRetries"`
+	}{e.toJSON(ancestors), e.RetryAfterSeconds, e.BackoffMultiplier, e.MaxRetries}
+}
@@ -0,0 +1,99 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestThrottleErr_RetryAfterHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantHeader string
+	}{
+		{"NewThrottleErr", NewThrottleErr(30, 1.5, 3), "30"},
+		{"wrapped", fmt.Errorf("rate limit: %w", NewThrottleErr(7, 2, 1)), "7"},
+		{"canonical 429", StatusToErr(http.StatusTooManyRequests), "1"},
+		{"zero retry after", NewThrottleErr(0, 2, 1), ""},
+		{"plain ErrTooManyRequests", ErrTooManyRequests, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantHeader {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestThrottleErr(t *testing.T) {
+	err := NewThrottleErr(30, 1.5, 3)
+
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Error("errors.Is(err, ErrTooManyRequests) = false, want true")
+	}
+	if !strings.Contains(err.Error(), "retry after 30s") {
+		t.Errorf("Error() = %q, should include the retry-after value", err.Error())
+	}
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	for _, want := range []string{`"retryAfterSeconds":30`, `"backoffMultiplier":1.5`, `"maxRetries":3`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json.Marshal() = %s, want it to contain %s", data, want)
+		}
+	}
+
+	var terr *ThrottleErr
+	if !errors.As(fmt.Errorf("wrap: %w", err), &terr) || terr.RetryAfterSeconds != 30 {
+		t.Errorf("errors.As() = %v, want the ThrottleErr", terr)
+	}
+}
+
+func TestNewThrottleErr_Fresh(t *testing.T) {
+	a, b := NewThrottleErr(30, 1.5, 3), NewThrottleErr(30, 1.5, 3)
+	if a == b || a.Serr == b.Serr || a.Serr == ErrTooManyRequests {
+		t.Fatal("NewThrottleErr() should return a new ThrottleErr around a new Serr")
+	}
+	a.SetMessage("Slow down")
+	a.RetryAfterSeconds = 60
+	if ErrTooManyRequests.GetMessage() != "Too many requests" || b.GetMessage() != "Too many requests" {
+		t.Error("SetMessage() on a ThrottleErr modified ErrTooManyRequests or another ThrottleErr")
+	}
+
+	if !errors.Is(NewErr(ErrTooManyRequests, "x", ""), HttpStatus2ErrMap[http.StatusTooManyRequests]) {
+		t.Error("errors.Is(NewErr(ErrTooManyRequests), HttpStatus2ErrMap[429]) = false, want true")
+	}
+}
+
+func TestErrThrottle(t *testing.T) {
+	terr, ok := StatusToErr(http.StatusTooManyRequests).(*ThrottleErr)
+	if !ok || terr != ErrThrottle {
+		t.Fatalf("StatusToErr(429) = %#v, want ErrThrottle", StatusToErr(http.StatusTooManyRequests))
+	}
+	if ErrThrottle.Serr == ErrTooManyRequests || !IsFrozen(ErrThrottle) {
+		t.Error("ErrThrottle should be frozen with its own Serr")
+	}
+	if !errors.Is(ErrThrottle, ErrTooManyRequests) || !errors.Is(ErrTooManyRequests, ErrThrottle) {
+		t.Error("ErrThrottle and ErrTooManyRequests should match each other with errors.Is")
+	}
+
+	c := Clone(ErrThrottle)
+	c.SetMessage("Slow down")
+	if ErrThrottle.GetMessage() != "Too many requests" {
+		t.Error("SetMessage() on a clone of ErrThrottle modified ErrThrottle")
+	}
+}
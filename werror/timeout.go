@@ -0,0 +1,77 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TimeoutErr is an ErrTimeout of a long-running operation retried up to MaxAttempts times,
+// which escalates to ErrServiceUnavailable once the attempts are exhausted, see Escalate.
+type TimeoutErr struct { //nolint:errname // lib
+	*Serr
+
+	Attempts    int `json:"attempts"    dc:"Number of attempts made"`
+	MaxAttempts int `json:"maxAttempts" dc:"Maximum number of attempts"`
+}
+
+// NewTimeoutErr creates a TimeoutErr for an operation that timed out after attempts of at most maxAttempts attempts.
+func NewTimeoutErr(attempts, maxAttempts int) *TimeoutErr {
+	err := &TimeoutErr{
+		Serr: &Serr{
+			error:      fmt.Errorf("%w: %s", ErrTimeout, ErrTimeout.GetMessage()),
+			HttpStatus: ErrTimeout.GetHttpStatus(),
+			Code:       ErrTimeout.GetCode(),
+			Message:    ErrTimeout.GetMessage(),
+		},
+		Attempts:    attempts,
+		MaxAttempts: maxAttempts,
+	}
+	observe(err)
+	return err
+}
+
+// Escalate returns ErrServiceUnavailable if the attempts are exhausted (Attempts >= MaxAttempts),
+// otherwise it counts another attempt and returns the TimeoutErr itself, so a retry loop can call it
+// after each timeout and give up once it returns ErrServiceUnavailable.
+func (e *TimeoutErr) Escalate() Err {
+	if e.Attempts >= e.MaxAttempts {
+		return ErrServiceUnavailable
+	}
+	e.Attempts++
+	return e
+}
+
+func (e *TimeoutErr) Error() string {
+	return fmt.Sprintf("%s (attempt %d of %d)", e.Serr.Error(), e.Attempts, e.MaxAttempts)
+}
+
+// Format formats the TimeoutErr like Serr.Format, with its own Error.
+func (e *TimeoutErr) Format(f fmt.State, verb rune) {
+	formatErr(f, verb, e)
+}
+
+// Clone returns a mutable copy of the TimeoutErr.
+func (e *TimeoutErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *TimeoutErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	return &c
+}
+
+// MarshalJSON serializes the TimeoutErr like Serr.MarshalJSON, with the attempt fields.
+func (e *TimeoutErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *TimeoutErr) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		Attempts    int `json:"attempts"`
+		MaxAttempts int `json:"maxAttempts"`
+	}{e.toJSON(ancestors), e.Attempts, e.MaxAttempts}
+}
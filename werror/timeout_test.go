@@ -0,0 +1,75 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTimeoutErr_Escalate(t *testing.T) {
+	tests := []struct {
+		name          string
+		attempts      int
+		maxAttempts   int
+		wantEscalated int // Number of Escalate calls returning the TimeoutErr before ErrServiceUnavailable
+	}{
+		{"three attempts", 1, 3, 2},
+		{"exhausted", 3, 3, 0},
+		{"beyond max", 5, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewTimeoutErr(tt.attempts, tt.maxAttempts)
+
+			retries := 0
+			for {
+				escalated := err.Escalate()
+				if errors.Is(escalated, ErrServiceUnavailable) {
+					break
+				}
+				if escalated != err {
+					t.Fatalf("Escalate() = %v, want the TimeoutErr", escalated)
+				}
+				retries++
+				if retries > tt.maxAttempts {
+					t.Fatal("Escalate() never escalated")
+				}
+			}
+			if retries != tt.wantEscalated {
+				t.Errorf("retries before escalation = %d, want %d", retries, tt.wantEscalated)
+			}
+			if err.Attempts != max(tt.attempts, tt.maxAttempts) {
+				t.Errorf("Attempts = %d, want %d", err.Attempts, max(tt.attempts, tt.maxAttempts))
+			}
+		})
+	}
+}
+
+func TestTimeoutErr(t *testing.T) {
+	err := NewTimeoutErr(2, 3)
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("errors.Is(err, ErrTimeout) = false, want true")
+	}
+	if !strings.Contains(err.Error(), "attempt 2 of 3") {
+		t.Errorf("Error() = %q, should include the attempts", err.Error())
+	}
+	if terr, ok := As[*TimeoutErr](err); !ok || terr != err {
+		t.Error("As[*TimeoutErr]() should find the TimeoutErr")
+	}
+
+	data, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() failed: %v", jerr)
+	}
+	if want := `{"code":"Timeout","message":"Timeout","attempts":2,"maxAttempts":3}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	clone, _ := Clone(err).(*TimeoutErr)
+	clone.Escalate()
+	if err.Attempts != 2 {
+		t.Error("Escalate() on a clone should not modify the original")
+	}
+}
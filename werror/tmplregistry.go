@@ -0,0 +1,126 @@
+package werror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// ErrTmplAlreadyRegistered is returned by TmplRegistry.Register when a message with the same ID is already registered.
+var ErrTmplAlreadyRegistered = errors.New("i18n message ID is already registered")
+
+// ErrTmplNotRegistered is returned by TmplRegistry.RenderByID for an unknown message ID.
+var ErrTmplNotRegistered = errors.New("i18n message ID is not registered")
+
+// ErrI18nMessageIDMissing is returned by TmplRegistry.Register for a message without ID.
+var ErrI18nMessageIDMissing = errors.New("i18n.Message.ID is missing")
+
+// TmplRegistry caches I18nErrTmpls by i18n message ID, so static messages are parsed once
+// instead of on every NewI18nErr call. Templates are built lazily on first use.
+// The zero value is ready to use, and it is safe for concurrent use.
+type TmplRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]*tmplEntry
+}
+
+type tmplEntry struct {
+	base Err
+	msg  *i18n.Message
+	once sync.Once
+	tmpl *I18nErrTmpl
+	err  error
+}
+
+// Register registers msg with base as the base Err of the I18nErrs rendered from it.
+// An error wrapping ErrTmplAlreadyRegistered is returned if msg's ID is taken.
+func (r *TmplRegistry) Register(msg *i18n.Message, base Err) error {
+	if base == nil {
+		return ErrBaseErrNil
+	}
+	if msg == nil || strings.TrimSpace(msg.ID) == "" {
+		return ErrI18nMessageIDMissing
+	}
+
+	return r.add(&tmplEntry{base: base, msg: msg})
+}
+
+// RegisterMessages registers msgs like Register with base as the base Err, but builds their templates right away,
+// so messages without i18n.Message.Other or with templates that fail to parse are rejected.
+// The other messages are registered, and the returned error joins the errors of all rejected messages.
+func (r *TmplRegistry) RegisterMessages(base Err, msgs []*i18n.Message) error {
+	if base == nil {
+		return ErrBaseErrNil
+	}
+
+	var errs []error
+	for i, msg := range msgs {
+		if msg == nil || strings.TrimSpace(msg.ID) == "" {
+			errs = append(errs, fmt.Errorf("i18n message %d: %w", i, ErrI18nMessageIDMissing))
+			continue
+		}
+		if msg.Other == "" {
+			errs = append(errs, fmt.Errorf("i18n message %s: %w", msg.ID, ErrI18nMessageOtherMissing))
+			continue
+		}
+		tmpl, err := NewI18nErrTmpl(base, msg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("i18n message %s: %w", msg.ID, err))
+			continue
+		}
+
+		entry := &tmplEntry{base: base, msg: msg, tmpl: tmpl}
+		entry.once.Do(func() {})
+		if err := r.add(entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// add adds entry unless its message ID is taken.
+func (r *TmplRegistry) add(entry *tmplEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[entry.msg.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrTmplAlreadyRegistered, entry.msg.ID)
+	}
+	if r.entries == nil {
+		r.entries = map[string]*tmplEntry{}
+	}
+	r.entries[entry.msg.ID] = entry
+	return nil
+}
+
+// MustRegister is like Register but panics on error, for registration at startup.
+func (r *TmplRegistry) MustRegister(msg *i18n.Message, base Err) {
+	if err := r.Register(msg, base); err != nil {
+		panic(err)
+	}
+}
+
+// Tmpl returns the I18nErrTmpl of the message registered with id, building it on first use.
+func (r *TmplRegistry) Tmpl(id string) (*I18nErrTmpl, error) {
+	r.mu.RLock()
+	entry, ok := r.entries[id]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTmplNotRegistered, id)
+	}
+
+	entry.once.Do(func() {
+		entry.tmpl, entry.err = NewI18nErrTmpl(entry.base, entry.msg)
+	})
+	return entry.tmpl, entry.err
+}
+
+// RenderByID renders the template of the message registered with id using data, see I18nErrTmpl.Render.
+func (r *TmplRegistry) RenderByID(id string, data any) (I18nErr, error) {
+	tmpl, err := r.Tmpl(id)
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Render(data)
+}
@@ -0,0 +1,130 @@
+package werror
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func TestTmplRegistry(t *testing.T) {
+	var reg TmplRegistry
+	reg.MustRegister(&i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} not found"}, ErrNotFound)
+	reg.MustRegister(&i18n.Message{ID: "Broken", Other: "{{.Name"}, ErrBadRequest)
+
+	got, err := reg.RenderByID("UserNotFound", map[string]any{"Name": "Alice"})
+	if err != nil {
+		t.Fatalf("RenderByID() unexpected error = %v", err)
+	}
+	if got.GetMessage() != "User Alice not found" || got.GetCode() != "UserNotFound" || got.GetHttpStatus() != 404 {
+		t.Errorf("RenderByID() = %v", got)
+	}
+
+	first, _ := reg.Tmpl("UserNotFound")
+	second, _ := reg.Tmpl("UserNotFound")
+	if first != second {
+		t.Error("Tmpl() should build the template once")
+	}
+
+	if _, err := reg.RenderByID("Broken", nil); err == nil {
+		t.Error("RenderByID() should fail for an invalid template")
+	}
+	if _, err := reg.RenderByID("Unknown", nil); !errors.Is(err, ErrTmplNotRegistered) {
+		t.Errorf("RenderByID() error = %v, want %v", err, ErrTmplNotRegistered)
+	}
+}
+
+func TestTmplRegistry_Register(t *testing.T) {
+	var reg TmplRegistry
+	msg := &i18n.Message{ID: "UserNotFound", Other: "User not found"}
+
+	tests := []struct {
+		name    string
+		msg     *i18n.Message
+		base    Err
+		wantErr error
+	}{
+		{"First registration", msg, ErrNotFound, nil},
+		{"Duplicate ID", msg, ErrNotFound, ErrTmplAlreadyRegistered},
+		{"Missing ID", &i18n.Message{Other: "User not found"}, ErrNotFound, ErrI18nMessageIDMissing},
+		{"Nil base", &i18n.Message{ID: "Other", Other: "Other"}, nil, ErrBaseErrNil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reg.Register(tt.msg, tt.base); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister() should panic for a duplicate ID")
+		}
+	}()
+	reg.MustRegister(msg, ErrNotFound)
+}
+
+func TestTmplRegistry_Concurrent(t *testing.T) {
+	var reg TmplRegistry
+	reg.MustRegister(&i18n.Message{ID: "UserNotFound", Other: "User {{.Name}} not found"}, ErrNotFound)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			if got, err := reg.RenderByID("UserNotFound", map[string]any{"Name": "Bob"}); err != nil ||
+				got.GetMessage() != "User Bob not found" {
+				t.Errorf("RenderByID() = %v, %v", got, err)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestTmplRegistry_RegisterMessages(t *testing.T) {
+	var reg TmplRegistry
+	reg.MustRegister(&i18n.Message{ID: "Taken", Other: "Taken"}, ErrConflict)
+
+	err := reg.RegisterMessages(ErrNotFound, []*i18n.Message{
+		{ID: "UserNotFound", Other: "User {{.Name}} not found"},
+		{ID: "NoOther"},
+		{ID: "Broken", Other: "{{.Name"},
+		nil,
+		{ID: "Taken", Other: "Taken again"},
+		{ID: "OrderNotFound", Other: "Order {{.ID}} not found"},
+	})
+
+	tests := []struct {
+		target error
+		id     string
+	}{
+		{ErrI18nMessageOtherMissing, "NoOther"},
+		{nil, "Broken"},
+		{ErrI18nMessageIDMissing, "3"},
+		{ErrTmplAlreadyRegistered, "Taken"},
+	}
+	for _, tt := range tests {
+		if tt.target != nil && !errors.Is(err, tt.target) {
+			t.Errorf("RegisterMessages() error = %v, want it to wrap %v", err, tt.target)
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.id) {
+			t.Errorf("RegisterMessages() error = %v, want it to list %s", err, tt.id)
+		}
+	}
+
+	for _, id := range []string{"UserNotFound", "OrderNotFound"} {
+		if _, rerr := reg.RenderByID(id, map[string]any{"Name": "Alice", "ID": 7}); rerr != nil {
+			t.Errorf("RenderByID(%s) unexpected error = %v", id, rerr)
+		}
+	}
+	for _, id := range []string{"NoOther", "Broken"} {
+		if _, terr := reg.Tmpl(id); !errors.Is(terr, ErrTmplNotRegistered) {
+			t.Errorf("Tmpl(%s) error = %v, want ErrTmplNotRegistered", id, terr)
+		}
+	}
+	if ierr, _ := reg.RenderByID("Taken", nil); ierr == nil || ierr.GetMessage() != "Taken" {
+		t.Errorf("RenderByID(Taken) = %v, want the first registration", ierr)
+	}
+}
@@ -0,0 +1,32 @@
+package werror
+
+import h "net/http"
+
+// ErrTransformer transforms an Err before it is written, e.g. to add a request ID, redact it or remap its status.
+// RedactDetails, RedactParams and CodeRemapper.Remap are ErrTransformers.
+// ErrTransformers should return a modified copy of the Err rather than modifying it, since it may be a sentinel.
+type ErrTransformer func(Err) Err
+
+// ChainTransformers returns an ErrTransformer applying transformers in order, each to the result of the previous one.
+// nil transformers are skipped.
+func ChainTransformers(transformers ...ErrTransformer) ErrTransformer {
+	return func(err Err) Err {
+		for _, t := range transformers {
+			if t != nil {
+				err = t(err)
+			}
+		}
+		return err
+	}
+}
+
+// WriteErrorTransformed writes err transformed by t to w like WriteError. A nil t writes err as is.
+func WriteErrorTransformed(w h.ResponseWriter, err Err, t ErrTransformer) {
+	if err == nil {
+		return
+	}
+	if t != nil {
+		err = t(err)
+	}
+	writeError(w, nil, err)
+}
@@ -0,0 +1,58 @@
+package werror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChainTransformers(t *testing.T) {
+	var order []string
+	addRequestID := func(err Err) Err {
+		order = append(order, "requestID")
+		c := Clone(err)
+		c.SetMetadata(map[string]any{"requestId": "req-123"})
+		return c
+	}
+	redact := func(err Err) Err {
+		order = append(order, "redact")
+		return RedactDetails(err)
+	}
+	var remapper CodeRemapper
+	remapper.Register(http.StatusInternalServerError, http.StatusOK)
+	remap := func(err Err) Err {
+		order = append(order, "remap")
+		return remapper.Remap(err)
+	}
+
+	err := NewErrFromError(ErrInternalServerError, errors.New("SELECT * FROM users"))
+	rec := httptest.NewRecorder()
+	WriteErrorTransformed(rec, err, ChainTransformers(addRequestID, nil, redact, remap))
+
+	if got := strings.Join(order, ","); got != "requestID,redact,remap" {
+		t.Errorf("transformers applied in order %s, want requestID,redact,remap", got)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `"requestId":"req-123"`) || !strings.Contains(body, `"code":"InternalServerError"`) {
+		t.Errorf("body = %s, want the code and the request ID", body)
+	}
+	if strings.Contains(body, "SELECT") {
+		t.Errorf("body = %s, want the sub-errors redacted", body)
+	}
+	if err.GetMetadata() != nil || len(err.GetSubErrors()) == 0 {
+		t.Error("the transformers should not modify the Err")
+	}
+}
+
+func TestWriteErrorTransformed_NilTransformer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteErrorTransformed(rec, ErrNotFound, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,70 @@
+package werror
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// FieldError is an error about a field of the request, with a machine-readable code, e.g. "InvalidFormat",
+// so clients can show localized messages from their own bundles, and a human-readable message.
+type FieldError struct {
+	Code    ErrCode `json:"code"    dc:"Machine-readable error code, empty for legacy errors"`
+	Message string  `json:"message" dc:"Human-readable error message"`
+}
+
+// ValidationErr is an Err with the errors of each invalid field of the request,
+// built with ErrBuilder.AddFieldCodeError, e.g. from ErrInvalidInput.
+type ValidationErr struct { //nolint:errname // lib
+	*Serr
+
+	Fields map[string][]FieldError `json:"fields" dc:"Errors of each invalid field"`
+}
+
+func (e *ValidationErr) Error() string {
+	return fmt.Sprintf("%s (invalid fields: %v)", e.Serr.Error(), slices.Sorted(maps.Keys(e.Fields)))
+}
+
+// Format formats the ValidationErr like Serr.Format, with its own Error.
+func (e *ValidationErr) Format(f fmt.State, verb rune) {
+	formatErr(f, verb, e)
+}
+
+// Clone returns a mutable copy of the ValidationErr.
+func (e *ValidationErr) Clone() Err {
+	return e.clone(map[*Serr]Err{})
+}
+
+func (e *ValidationErr) clone(copies map[*Serr]Err) Err {
+	c := *e
+	c.Serr = new(Serr)
+	e.Serr.cloneInto(c.Serr, &c, copies)
+	c.Fields = cloneFields(e.Fields)
+	return &c
+}
+
+// cloneFields returns a deep copy of the field errors fields, nil if it is nil.
+func cloneFields(fields map[string][]FieldError) map[string][]FieldError {
+	if fields == nil {
+		return nil
+	}
+	c := make(map[string][]FieldError, len(fields))
+	for field, errs := range fields {
+		c[field] = slices.Clone(errs)
+	}
+	return c
+}
+
+// MarshalJSON serializes the ValidationErr like Serr.MarshalJSON, with the field errors.
+func (e *ValidationErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonValue(map[*Serr]bool{}))
+}
+
+func (e *ValidationErr) jsonValue(ancestors map[*Serr]bool) any {
+	return struct {
+		serrJSON
+
+		Fields map[string][]FieldError `json:"fields"`
+	}{e.toJSON(ancestors), e.Fields}
+}
@@ -0,0 +1,86 @@
+package werror
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidationErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(*ErrBuilder)
+		wantJSON string
+	}{
+		{
+			name: "Coded field error",
+			build: func(b *ErrBuilder) {
+				b.AddFieldCodeError("email", "InvalidFormat", "Invalid email format")
+			},
+			wantJSON: `{"code":"InvalidInput","message":"Some request inputs are not valid",` +
+				`"fields":{"email":[{"code":"InvalidFormat","message":"Invalid email format"}]}}`,
+		},
+		{
+			name: "Legacy field error",
+			build: func(b *ErrBuilder) {
+				b.LegacyAddFieldError("name", "Name is required")
+			},
+			wantJSON: `{"code":"InvalidInput","message":"Some request inputs are not valid",` +
+				`"fields":{"name":[{"code":"","message":"Name is required"}]}}`,
+		},
+		{
+			name: "Several errors for a field",
+			build: func(b *ErrBuilder) {
+				b.AddFieldCodeError("password", "TooShort", "Too short").
+					AddFieldCodeError("password", "NoDigit", "Must contain a digit")
+			},
+			wantJSON: `{"code":"InvalidInput","message":"Some request inputs are not valid",` +
+				`"fields":{"password":[{"code":"TooShort","message":"Too short"},` +
+				`{"code":"NoDigit","message":"Must contain a digit"}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewErrBuilder(ErrInvalidInput)
+			tt.build(b)
+			err := b.Build()
+			if _, ok := err.(*ValidationErr); !ok {
+				t.Fatalf("Build() = %T, want *ValidationErr", err)
+			}
+
+			data, jerr := json.Marshal(err)
+			if jerr != nil {
+				t.Fatalf("json.Marshal() failed: %v", jerr)
+			}
+			if string(data) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestValidationErr_Clone(t *testing.T) {
+	err, _ := NewErrBuilder(ErrInvalidInput).
+		AddFieldCodeError("email", "InvalidFormat", "Invalid email format").
+		Build().(*ValidationErr)
+
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Error("errors.Is(err, ErrInvalidInput) = false, want true")
+	}
+	if !strings.Contains(err.Error(), "invalid fields: [email]") {
+		t.Errorf("Error() = %q, should include the invalid fields", err.Error())
+	}
+
+	clone, _ := Clone(err).(*ValidationErr)
+	clone.Fields["email"][0].Code = "Taken"
+	if err.Fields["email"][0].Code != "InvalidFormat" {
+		t.Error("modifying a clone should not modify the original")
+	}
+
+	derived, _ := NewErrBuilder(err).AddFieldCodeError("name", "Required", "Name is required").Build().(*ValidationErr)
+	if len(derived.Fields) != 2 || len(err.Fields) != 1 {
+		t.Errorf("derived fields = %v, original fields = %v, want the original extended in a copy",
+			derived.Fields, err.Fields)
+	}
+}
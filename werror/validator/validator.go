@@ -0,0 +1,42 @@
+// Package validator integrates werror with go-playground/validator.
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	vd "github.com/go-playground/validator/v10"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+// FieldNamer returns the name of the field of fe to use in errors, e.g. its JSON name or a translation.
+type FieldNamer func(fe vd.FieldError) string
+
+// FromValidationErrors converts err to a werror.ErrInvalidInput if it wraps validator.ValidationErrors,
+// with one sub-error per failing field whose Metadata contains its "field", "tag" and "param".
+// Other errors, including nil, are returned unchanged.
+func FromValidationErrors(err error) error {
+	return FromValidationErrorsWithNamer(err, nil)
+}
+
+// FromValidationErrorsWithNamer is like FromValidationErrors, but field names are translated with namer.
+// If namer is nil, the struct field names are used.
+func FromValidationErrorsWithNamer(err error, namer FieldNamer) error {
+	var verrs vd.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	werr := werror.NewErr(werror.ErrInvalidInput, "", "")
+	for _, fe := range verrs {
+		field := fe.Field()
+		if namer != nil {
+			field = namer(fe)
+		}
+		sub := werror.NewErr(werror.ErrInvalidInput, fmt.Sprintf("Field %s failed on the %s rule", field, fe.Tag()), "")
+		sub.SetMetadata(map[string]any{"field": field, "tag": fe.Tag(), "param": fe.Param()})
+		werr.AddSubErrors(sub)
+	}
+	return werr
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	vd "github.com/go-playground/validator/v10"
+
+	"github.com/daotl/go-web-common/werror"
+)
+
+type user struct {
+	Name  string `json:"name"  validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age"   validate:"gte=18"`
+}
+
+func TestFromValidationErrors(t *testing.T) {
+	err := vd.New().Struct(user{Email: "not-an-email", Age: 16})
+
+	got := FromValidationErrors(err)
+	werr, ok := got.(werror.Err)
+	if !ok {
+		t.Fatalf("FromValidationErrors() = %T, want a werror.Err", got)
+	}
+	if !errors.Is(werr, werror.ErrInvalidInput) {
+		t.Errorf("FromValidationErrors() = %v, want werror.ErrInvalidInput", werr)
+	}
+
+	want := []map[string]any{
+		{"field": "Name", "tag": "required", "param": ""},
+		{"field": "Email", "tag": "email", "param": ""},
+		{"field": "Age", "tag": "gte", "param": "18"},
+	}
+	subs := werr.GetSubErrors()
+	if len(subs) != len(want) {
+		t.Fatalf("GetSubErrors() = %v, want %d sub-errors", subs, len(want))
+	}
+	for i, sub := range subs {
+		if !reflect.DeepEqual(sub.GetMetadata(), want[i]) {
+			t.Errorf("sub-error %d Metadata = %v, want %v", i, sub.GetMetadata(), want[i])
+		}
+	}
+}
+
+func TestFromValidationErrorsWithNamer(t *testing.T) {
+	err := vd.New().Struct(user{Name: "Alice", Email: "alice@example.com"})
+
+	jsonName := func(fe vd.FieldError) string {
+		f, _ := reflect.TypeFor[user]().FieldByName(fe.StructField())
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	}
+	werr, _ := FromValidationErrorsWithNamer(err, jsonName).(werror.Err)
+	if werr == nil || len(werr.GetSubErrors()) != 1 {
+		t.Fatalf("FromValidationErrorsWithNamer() = %v, want one sub-error", werr)
+	}
+	sub := werr.GetSubErrors()[0]
+	if field := sub.GetMetadata().(map[string]any)["field"]; field != "age" {
+		t.Errorf("field = %v, want age", field)
+	}
+	if !strings.Contains(sub.GetMessage(), "age") {
+		t.Errorf("GetMessage() = %q, should use the translated name", sub.GetMessage())
+	}
+}
+
+func TestFromValidationErrors_Unchanged(t *testing.T) {
+	plain := errors.New("boom")
+	if got := FromValidationErrors(plain); got != plain {
+		t.Errorf("FromValidationErrors() = %v, want %v unchanged", got, plain)
+	}
+	if got := FromValidationErrors(nil); got != nil {
+		t.Errorf("FromValidationErrors(nil) = %v, want nil", got)
+	}
+}
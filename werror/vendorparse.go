@@ -0,0 +1,167 @@
+package werror
+
+import (
+	"encoding/json"
+	"maps"
+	h "net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrParser parses the error JSON of a third-party API, e.g. AWS or Stripe, into a remote Err,
+// reading the code, message and status from configurable fields. Create it with an ErrParserBuilder
+// or use a preset like AWSErrParser. It is safe for concurrent use.
+type ErrParser struct {
+	codeField    string
+	messageField string
+	statusField  string
+	// Base Errs by vendor code, giving the status if there is no status field
+	bases map[string]Err
+	// Normalizes the vendor code, e.g. strips the namespace of AWS codes
+	normalize func(string) string
+}
+
+// ErrParserBuilder builds an ErrParser.
+type ErrParserBuilder struct {
+	p ErrParser
+}
+
+// NewErrParserBuilder creates an ErrParserBuilder for errors with "code" and "message" fields and no status field.
+func NewErrParserBuilder() *ErrParserBuilder {
+	return &ErrParserBuilder{p: ErrParser{codeField: "code", messageField: "message"}}
+}
+
+// WithCodeField sets the field of the code, nested fields are separated by dots, e.g. "error.code".
+func (b *ErrParserBuilder) WithCodeField(field string) *ErrParserBuilder {
+	b.p.codeField = field
+	return b
+}
+
+// WithMessageField sets the field of the message, nested fields are separated by dots, e.g. "error.message".
+func (b *ErrParserBuilder) WithMessageField(field string) *ErrParserBuilder {
+	b.p.messageField = field
+	return b
+}
+
+// WithStatusField sets the field of the HTTP status, a number or numeric string.
+// Nested fields are separated by dots, e.g. "error.status".
+func (b *ErrParserBuilder) WithStatusField(field string) *ErrParserBuilder {
+	b.p.statusField = field
+	return b
+}
+
+// WithCodeMapping makes errors with the vendor code have the status of base if they have no status field.
+func (b *ErrParserBuilder) WithCodeMapping(code string, base Err) *ErrParserBuilder {
+	if b.p.bases == nil {
+		b.p.bases = map[string]Err{}
+	}
+	b.p.bases[code] = base
+	return b
+}
+
+// Build creates the ErrParser, the builder can be reused.
+func (b *ErrParserBuilder) Build() *ErrParser {
+	p := b.p
+	p.bases = maps.Clone(b.p.bases)
+	return &p
+}
+
+// AWSErrParser returns an ErrParser for the JSON errors of AWS services, e.g.
+// {"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","message":"Requested resource not found"},
+// with the namespace stripped from the code and the statuses of common codes.
+func AWSErrParser() *ErrParser {
+	p := NewErrParserBuilder().
+		WithCodeField("__type").
+		WithCodeMapping("ValidationException", ErrBadRequest).
+		WithCodeMapping("SerializationException", ErrBadRequest).
+		WithCodeMapping("UnrecognizedClientException", ErrUnauthorized).
+		WithCodeMapping("InvalidSignatureException", ErrUnauthorized).
+		WithCodeMapping("AccessDeniedException", ErrForbidden).
+		WithCodeMapping("ResourceNotFoundException", ErrResourceNotFound).
+		WithCodeMapping("ResourceInUseException", ErrConflict).
+		WithCodeMapping("ConditionalCheckFailedException", ErrPreconditionFailed).
+		WithCodeMapping("ThrottlingException", ErrTooManyRequests).
+		WithCodeMapping("ProvisionedThroughputExceededException", ErrTooManyRequests).
+		WithCodeMapping("InternalServerError", ErrInternalServerError).
+		WithCodeMapping("ServiceUnavailable", ErrServiceUnavailable).
+		Build()
+	p.normalize = func(code string) string {
+		if i := strings.LastIndexByte(code, '#'); i >= 0 {
+			return code[i+1:]
+		}
+		return code
+	}
+	return p
+}
+
+// StripeErrParser returns an ErrParser for Stripe errors, e.g.
+// {"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}},
+// with the error type as code and the statuses Stripe documents for each type.
+func StripeErrParser() *ErrParser {
+	return NewErrParserBuilder().
+		WithCodeField("error.type").
+		WithMessageField("error.message").
+		WithCodeMapping("invalid_request_error", ErrBadRequest).
+		WithCodeMapping("authentication_error", ErrUnauthorized).
+		WithCodeMapping("card_error", NewBaseErr(h.StatusPaymentRequired, "PaymentRequired", "Payment required")).
+		WithCodeMapping("idempotency_error", ErrIdempotencyConflict).
+		WithCodeMapping("rate_limit_error", ErrTooManyRequests).
+		WithCodeMapping("api_error", ErrInternalServerError).
+		Build()
+}
+
+// Parse creates a remote Err (see OriginRemote) from the error JSON data. Its status is read from the status field,
+// or the status of the base Err mapped to the code, or 500. It returns ErrEnvelopeCodeMissing if data has no code.
+func (p *ErrParser) Parse(data []byte) (Err, error) {
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+
+	code, _ := lookupField(body, p.codeField).(string)
+	if p.normalize != nil {
+		code = p.normalize(code)
+	}
+	if code == "" {
+		return nil, ErrEnvelopeCodeMissing
+	}
+	msg, _ := lookupField(body, p.messageField).(string)
+
+	status, ok := statusValue(lookupField(body, p.statusField))
+	if !ok {
+		status = h.StatusInternalServerError
+		if base, found := p.bases[code]; found {
+			status = base.GetHttpStatus()
+		}
+	}
+	return NewRemoteErr(status, ErrCodeString(code), msg), nil
+}
+
+// lookupField returns the value of the dot-separated field path in body, nil if it is missing.
+func lookupField(body map[string]any, path string) any {
+	if path == "" {
+		return nil
+	}
+	var v any = body
+	for key := range strings.SplitSeq(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// statusValue returns the HTTP status v, a JSON number or numeric string, holds.
+func statusValue(v any) (int, bool) {
+	switch x := v.(type) {
+	case float64:
+		return int(x), x >= 100 && x < 600
+	case string:
+		status, err := strconv.Atoi(x)
+		return status, err == nil && status >= 100 && status < 600
+	default:
+		return 0, false
+	}
+}
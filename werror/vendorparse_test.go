@@ -0,0 +1,113 @@
+package werror
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrParser_Parse(t *testing.T) {
+	custom := NewErrParserBuilder().
+		WithCodeField("Code").
+		WithMessageField("Message").
+		WithStatusField("status").
+		Build()
+
+	tests := []struct {
+		name       string
+		parser     *ErrParser
+		data       string
+		wantStatus int
+		wantCode   ErrCode
+		wantMsg    string
+		wantErr    error
+	}{
+		{
+			name:   "AWS namespaced code",
+			parser: AWSErrParser(),
+			data: `{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException",` +
+				`"message":"Requested resource not found"}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "ResourceNotFoundException",
+			wantMsg:    "Requested resource not found",
+		},
+		{
+			name:       "AWS throttling",
+			parser:     AWSErrParser(),
+			data:       `{"__type":"ThrottlingException","message":"Rate exceeded"}`,
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   "ThrottlingException",
+			wantMsg:    "Rate exceeded",
+		},
+		{
+			name:       "AWS unknown code",
+			parser:     AWSErrParser(),
+			data:       `{"__type":"SomethingNewException","message":"New"}`,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "SomethingNewException",
+			wantMsg:    "New",
+		},
+		{
+			name:   "Stripe card error",
+			parser: StripeErrParser(),
+			data: `{"error":{"type":"card_error","code":"card_declined","decline_code":"generic_decline",` +
+				`"message":"Your card was declined."}}`,
+			wantStatus: http.StatusPaymentRequired,
+			wantCode:   "card_error",
+			wantMsg:    "Your card was declined.",
+		},
+		{
+			name:   "Stripe invalid request",
+			parser: StripeErrParser(),
+			data: `{"error":{"type":"invalid_request_error","param":"amount",` +
+				`"message":"Missing required param: amount."}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request_error",
+			wantMsg:    "Missing required param: amount.",
+		},
+		{
+			name:       "Status field",
+			parser:     custom,
+			data:       `{"Code":"NoSuchBucket","Message":"The bucket does not exist","status":404}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NoSuchBucket",
+			wantMsg:    "The bucket does not exist",
+		},
+		{
+			name:       "Numeric string status",
+			parser:     custom,
+			data:       `{"Code":"SlowDown","Message":"Slow down","status":"503"}`,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "SlowDown",
+			wantMsg:    "Slow down",
+		},
+		{name: "Missing code", parser: StripeErrParser(), data: `{"error":{}}`, wantErr: ErrEnvelopeCodeMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parser.Parse([]byte(tt.data))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error = %v", err)
+			}
+			if got.GetHttpStatus() != tt.wantStatus || got.GetCode() != tt.wantCode || got.GetMessage() != tt.wantMsg {
+				t.Errorf("Parse() = %v %v %q, want %v %v %q", got.GetHttpStatus(), got.GetCode(), got.GetMessage(),
+					tt.wantStatus, tt.wantCode, tt.wantMsg)
+			}
+			if OriginOf(got) != OriginRemote {
+				t.Errorf("GetOrigin() = %v, want %v", OriginOf(got), OriginRemote)
+			}
+		})
+	}
+}
+
+func TestErrParser_InvalidJSON(t *testing.T) {
+	if _, err := AWSErrParser().Parse([]byte(`<Error>`)); err == nil {
+		t.Error("Parse() of invalid JSON should fail")
+	}
+}
@@ -0,0 +1,17 @@
+package werror
+
+// Wrap returns a new Err wrapping e with msg appended to e's message as detail and a copy of e's params,
+// like NewErrWithParams(e, "", msg, nil), e.g. ErrNotFound.Wrap("user 42") has the message "Not found: user 42".
+// e is not modified.
+func (e *Serr) Wrap(msg string) Err {
+	return NewErrWithParams(e, "", msg, nil)
+}
+
+// WrapErr is like Wrap with the text of err as detail, so it must only be used for errors whose text
+// may reach clients, see NewErrFromError otherwise. It returns nil if err is nil.
+func (e *Serr) WrapErr(err error) Err {
+	if err == nil {
+		return nil
+	}
+	return e.Wrap(err.Error())
+}
@@ -0,0 +1,52 @@
+package werror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSerr_Wrap(t *testing.T) {
+	//nolint:errcheck // type must match
+	base := ErrNotFound.(*Serr)
+
+	tests := []struct {
+		name    string
+		wrap    func() Err
+		wantMsg string
+	}{
+		{"Wrap", func() Err { return base.Wrap("user 42") }, "Not found: user 42"},
+		{"WrapErr", func() Err { return base.WrapErr(errors.New("no rows")) }, "Not found: no rows"},
+		{
+			"Wrap twice",
+			func() Err { return base.Wrap("user 42").(*Serr).Wrap("in org 7") },
+			"Not found: user 42: in org 7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := tt.wrap()
+
+			if !errors.Is(wrapped, base) {
+				t.Error("errors.Is(wrapped, base) = false, want true")
+			}
+			if got := wrapped.GetMessage(); got != tt.wantMsg || !strings.Contains(got, base.GetMessage()) {
+				t.Errorf("GetMessage() = %q, want %q", got, tt.wantMsg)
+			}
+			if wrapped.GetHttpStatus() != base.GetHttpStatus() || wrapped.GetCode() != base.GetCode() {
+				t.Errorf("Wrap() = %v %v, want the status and code of the base",
+					wrapped.GetHttpStatus(), wrapped.GetCode())
+			}
+			if base.GetMessage() != "Not found" {
+				t.Errorf("base message changed to %q", base.GetMessage())
+			}
+		})
+	}
+}
+
+func TestSerr_WrapErr_Nil(t *testing.T) {
+	//nolint:errcheck // type must match
+	if got := ErrNotFound.(*Serr).WrapErr(nil); got != nil {
+		t.Errorf("WrapErr(nil) = %v, want nil", got)
+	}
+}